@@ -0,0 +1,125 @@
+package freshservice
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+	"time"
+)
+
+// AssetType represents a Freshservice asset type
+type AssetType struct {
+	ID                int       `json:"id"`
+	Name              string    `json:"name"`
+	ParentAssetTypeID *int      `json:"parent_asset_type_id"`
+	Description       string    `json:"description"`
+	Visible           bool      `json:"visible"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// AssetTypeRequest represents the request body for creating and updating asset types.
+// Fields left empty are not changed by an update. Description is a pointer so an
+// update can clear it.
+type AssetTypeRequest struct {
+	Name              string  `json:"name,omitempty"`
+	ParentAssetTypeID *int    `json:"parent_asset_type_id,omitempty"`
+	Description       *string `json:"description,omitempty"`
+	Visible           *bool   `json:"visible,omitempty"`
+}
+
+// assetTypeResponse represents the API response for single asset type operations
+type assetTypeResponse struct {
+	AssetType *AssetType `json:"asset_type"`
+}
+
+// assetTypeFieldsResponse represents the API response for the fields of an asset type
+type assetTypeFieldsResponse struct {
+	AssetTypeFields []AssetTypeFieldGroup `json:"asset_type_fields"`
+}
+
+// assetTypeFieldResponse represents the API response for single asset type field operations
+type assetTypeFieldResponse struct {
+	AssetTypeField *AssetTypeField `json:"asset_type_field"`
+}
+
+// AssetTypesService manages asset types and their fields
+type AssetTypesService struct {
+	client *Client
+}
+
+// Get returns an asset type. The error matches ErrNotFound when it does not exist.
+func (s *AssetTypesService) Get(ctx context.Context, id int) (*AssetType, error) {
+	return s.send(ctx, "GET", fmt.Sprintf("/asset_types/%d", id), nil)
+}
+
+// Create creates an asset type
+func (s *AssetTypesService) Create(ctx context.Context, assetType *AssetTypeRequest) (*AssetType, error) {
+	return s.send(ctx, "POST", "/asset_types", assetType)
+}
+
+// Update changes the fields of an asset type set in the request
+func (s *AssetTypesService) Update(ctx context.Context, id int, assetType *AssetTypeRequest) (*AssetType, error) {
+	return s.send(ctx, "PUT", fmt.Sprintf("/asset_types/%d", id), assetType)
+}
+
+// Delete deletes an asset type
+func (s *AssetTypesService) Delete(ctx context.Context, id int) error {
+	return s.client.do(ctx, "DELETE", fmt.Sprintf("/asset_types/%d", id), nil, nil)
+}
+
+// List iterates over every asset type, fetching pages as needed
+func (s *AssetTypesService) List(ctx context.Context) iter.Seq2[AssetType, error] {
+	return paginate[AssetType](ctx, s.client, "/asset_types", url.Values{}, "asset_types")
+}
+
+// Fields returns the field definitions of an asset type, grouped into sections as
+// shown in Freshservice. They include the built-in fields of every asset, and the
+// fields inherited from parent asset types.
+func (s *AssetTypesService) Fields(ctx context.Context, id int) ([]AssetTypeFieldGroup, error) {
+	var resp assetTypeFieldsResponse
+	if err := s.client.do(ctx, "GET", fmt.Sprintf("/asset_types/%d/fields", id), nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.AssetTypeFields, nil
+}
+
+// CreateField adds a custom field to an asset type. The API does not always return
+// the ID of the new field, in which case the returned field has ID 0.
+//
+// Unlike Fields, creating, updating and deleting fields is not part of the public API
+// reference, so these endpoints may change without notice.
+func (s *AssetTypesService) CreateField(ctx context.Context, id int, field *AssetTypeFieldRequest) (*AssetTypeField, error) {
+	var resp assetTypeFieldResponse
+	if err := s.client.do(ctx, "POST", fmt.Sprintf("/asset_types/%d/fields", id), field, &resp); err != nil {
+		return nil, err
+	}
+	if resp.AssetTypeField == nil {
+		return &AssetTypeField{}, nil
+	}
+	return resp.AssetTypeField, nil
+}
+
+// UpdateField changes a custom field of an asset type. The data type of a field
+// can't be changed.
+func (s *AssetTypesService) UpdateField(ctx context.Context, id, fieldID int, field *AssetTypeFieldRequest) error {
+	return s.client.do(ctx, "PUT", fmt.Sprintf("/asset_types/%d/fields/%d", id, fieldID), field, nil)
+}
+
+// DeleteField deletes a custom field of an asset type
+func (s *AssetTypesService) DeleteField(ctx context.Context, id, fieldID int) error {
+	return s.client.do(ctx, "DELETE", fmt.Sprintf("/asset_types/%d/fields/%d", id, fieldID), nil, nil)
+}
+
+// send sends an asset type request and returns the asset type of the response
+func (s *AssetTypesService) send(ctx context.Context, method, endpoint string, body interface{}) (*AssetType, error) {
+	var resp assetTypeResponse
+	if err := s.client.do(ctx, method, endpoint, body, &resp); err != nil {
+		return nil, err
+	}
+	if resp.AssetType == nil {
+		return nil, fmt.Errorf("response for %s %s does not contain an asset type", method, endpoint)
+	}
+	return resp.AssetType, nil
+}
@@ -0,0 +1,60 @@
+package freshservice
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestAssetTypes_list(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/asset_types" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// A short page ends the listing without a Link header
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{
+			"asset_types": []map[string]interface{}{
+				{"id": 25, "name": "Hardware", "visible": true},
+				{"id": 26, "name": "Laptop", "parent_asset_type_id": 25, "visible": true},
+			},
+		})
+	})
+
+	assetTypes, err := Collect(client.AssetTypes.List(context.Background()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(assetTypes) != 2 {
+		t.Fatalf("expected 2 asset types, got %+v", assetTypes)
+	}
+	if laptop := assetTypes[1]; laptop.Name != "Laptop" || laptop.ParentAssetTypeID == nil || *laptop.ParentAssetTypeID != 25 {
+		t.Errorf("unexpected asset type %+v", laptop)
+	}
+}
+
+func TestAssetTypes_fields(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{
+			"asset_type_fields": []map[string]interface{}{
+				{
+					"field_header": "Laptop",
+					"fields": []map[string]interface{}{
+						{"id": 7, "name": "serial_26", "label": "Serial", "data_type": FieldTypeText},
+					},
+				},
+			},
+		})
+	})
+
+	groups, err := client.AssetTypes.Fields(context.Background(), 26)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 1 || len(groups[0].Fields) != 1 {
+		t.Fatalf("unexpected field groups %+v", groups)
+	}
+	if field := groups[0].Fields[0]; field.ShortName() != "serial" || field.ID != 7 {
+		t.Errorf("unexpected field %+v", field)
+	}
+}
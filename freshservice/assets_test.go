@@ -0,0 +1,222 @@
+package freshservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// newTestClient returns a client of a server handling requests under /api/v2
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(testAPIKey, "", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.MaxRetries = 0
+	return client
+}
+
+// writeJSON writes v as the JSON body of a response
+func writeJSON(t *testing.T, w http.ResponseWriter, status int, v interface{}) {
+	t.Helper()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAssets_createAndGet(t *testing.T) {
+	var created AssetRequest
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if user, _, _ := r.BasicAuth(); user != testAPIKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.Method + " " + r.URL.Path {
+		case "POST /api/v2/assets":
+			if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+				t.Error(err)
+			}
+			writeJSON(t, w, http.StatusCreated, map[string]interface{}{
+				"asset": map[string]interface{}{"id": 9001, "display_id": 42, "name": created.Name, "type_fields": created.TypeFields},
+			})
+		case "GET /api/v2/assets/42":
+			if r.URL.Query().Get("include") != "type_fields" {
+				t.Errorf("type fields were not requested: %s", r.URL.RawQuery)
+			}
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{
+				"asset": map[string]interface{}{"id": 9001, "display_id": 42, "name": created.Name, "type_fields": created.TypeFields},
+			})
+		default:
+			writeJSON(t, w, http.StatusNotFound, map[string]interface{}{"message": "Record not found"})
+		}
+	})
+	ctx := context.Background()
+
+	asset, err := client.Assets.Create(ctx, &AssetRequest{
+		Name:        "Laptop 1",
+		AssetTypeID: 26,
+		TypeFields:  map[string]interface{}{"serial_26": "SN-1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if asset.DisplayID != 42 || created.AssetTypeID != 26 {
+		t.Fatalf("unexpected asset %+v created from %+v", asset, created)
+	}
+
+	asset, err = client.Assets.Get(ctx, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if asset.Name != "Laptop 1" || asset.TypeFields["serial_26"] != "SN-1" {
+		t.Fatalf("unexpected asset %+v", asset)
+	}
+
+	_, err = client.Assets.Get(ctx, 43)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != "Record not found" {
+		t.Fatalf("expected the API error message, got %v", err)
+	}
+}
+
+func TestAssets_search(t *testing.T) {
+	var queries []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+
+		// Two full pages linked with the Link header, then a short last page
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		assets := []map[string]interface{}{{"display_id": page*10 + 1}}
+		if page < 3 {
+			next := *r.URL
+			query := next.Query()
+			query.Set("page", strconv.Itoa(page+1))
+			next.RawQuery = query.Encode()
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s%s>; rel="next"`, r.Host, next.RequestURI()))
+			assets = append(assets, map[string]interface{}{"display_id": page*10 + 2})
+		}
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{"assets": assets})
+	})
+	client.PageSize = 2
+
+	assets, err := Collect(client.Assets.Search(context.Background(), "name:'Laptop'"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []int
+	for _, asset := range assets {
+		ids = append(ids, asset.DisplayID)
+	}
+	if fmt.Sprint(ids) != "[11 12 21 22 31]" {
+		t.Errorf("unexpected assets %v", ids)
+	}
+	if len(queries) != 3 || queries[0] != "include=type_fields&page=1&per_page=2&search=name%3A%27Laptop%27" {
+		t.Errorf("unexpected queries %v", queries)
+	}
+
+	// Stopping early doesn't fetch the following pages
+	queries = nil
+	for asset, err := range client.Assets.List(context.Background(), &AssetListOptions{Trashed: true}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if asset.DisplayID == 12 {
+			break
+		}
+	}
+	if len(queries) != 1 || queries[0] != "include=type_fields&page=1&per_page=2&trashed=true" {
+		t.Errorf("unexpected queries %v", queries)
+	}
+}
+
+func TestAssets_listError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusBadRequest, map[string]interface{}{
+			"description": "Validation failed",
+			"errors":      []map[string]interface{}{{"field": "filter", "message": "is invalid", "code": "invalid_value"}},
+		})
+	})
+
+	_, err := Collect(client.Assets.List(context.Background(), &AssetListOptions{Filter: "bogus"}))
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest || len(apiErr.Errors) != 1 {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Error("expected a validation error not to match ErrNotFound")
+	}
+}
+
+func TestAssets_trash(t *testing.T) {
+	trashed := map[int]bool{7: true, 8: true}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v2/assets":
+			if r.URL.Query().Get("trashed") != "true" || r.URL.Query().Has("filter") {
+				t.Errorf("unexpected query %s", r.URL.RawQuery)
+			}
+			var assets []map[string]interface{}
+			if trashed[7] {
+				assets = append(assets, map[string]interface{}{"display_id": 7, "name": "Old laptop"})
+			}
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{"assets": assets})
+		case "PUT /api/v2/assets/7/restore":
+			delete(trashed, 7)
+			w.WriteHeader(http.StatusNoContent)
+		case "PUT /api/v2/assets/7/delete_forever", "PUT /api/v2/assets/8/delete_forever":
+			var displayID int
+			fmt.Sscanf(r.URL.Path, "/api/v2/assets/%d/delete_forever", &displayID)
+			if !trashed[displayID] {
+				writeJSON(t, w, http.StatusNotFound, map[string]interface{}{"message": "Record not found"})
+				return
+			}
+			delete(trashed, displayID)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeJSON(t, w, http.StatusNotFound, map[string]interface{}{"message": "Record not found"})
+		}
+	})
+	ctx := context.Background()
+
+	asset, err := client.Assets.GetTrashed(ctx, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if asset.Name != "Old laptop" {
+		t.Errorf("unexpected asset %+v", asset)
+	}
+
+	if err := client.Assets.Restore(ctx, 7); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Assets.GetTrashed(ctx, 7); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound after restoring, got %v", err)
+	}
+	if err := client.Assets.DeleteForever(ctx, 7); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an asset outside the trash, got %v", err)
+	}
+	if err := client.Assets.DeleteForever(ctx, 8); err != nil {
+		t.Fatal(err)
+	}
+	if trashed[8] {
+		t.Error("expected asset 8 to be deleted forever")
+	}
+}
@@ -0,0 +1,255 @@
+package freshservice
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the number of times a request is retried by default
+	DefaultMaxRetries = 5
+	// DefaultMaxRetryWait is the longest the client waits between two attempts by default
+	DefaultMaxRetryWait = 60 * time.Second
+	// DefaultUserAgent is the User-Agent header sent unless the client sets its own
+	DefaultUserAgent = "freshservice-go"
+)
+
+// Client is a client of the Freshservice API v2. It is safe for concurrent use, and
+// all requests of a client share its rate limit.
+type Client struct {
+	APIKey       string
+	Domain       string
+	BaseURL      string
+	HTTPClient   *http.Client
+	UserAgent    string
+	MaxRetries   int
+	MaxRetryWait time.Duration
+	PageSize     int
+
+	// ReadOnly makes the client refuse every request other than GET, so it can't change anything
+	ReadOnly bool
+
+	// SensitiveTypeFields are type field names (without the asset type ID suffix) whose values are masked in logs
+	SensitiveTypeFields map[string]bool
+
+	// Logger receives every request, response and retry. Nothing is logged when it is nil.
+	Logger Logger
+
+	// Assets manages assets
+	Assets *AssetsService
+	// AssetTypes manages asset types and their fields
+	AssetTypes *AssetTypesService
+
+	// limiter spaces out requests across all parallel operations
+	limiter *rateLimiter
+}
+
+// NewClient creates a new client. The API is reached at baseURL when it is set,
+// and at the API path of the domain otherwise.
+func NewClient(apiKey, domain, baseURL string) (*Client, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("an API key is required")
+	}
+	if domain == "" && baseURL == "" {
+		return nil, fmt.Errorf("a domain or base URL is required")
+	}
+
+	var err error
+	if domain != "" {
+		if domain, err = NormalizeDomain(domain); err != nil {
+			return nil, err
+		}
+	}
+
+	if baseURL != "" {
+		if baseURL, err = NormalizeBaseURL(baseURL); err != nil {
+			return nil, err
+		}
+	} else {
+		baseURL = "https://" + domain + DefaultAPIPath
+	}
+
+	c := &Client{
+		APIKey:       apiKey,
+		Domain:       domain,
+		BaseURL:      baseURL,
+		HTTPClient:   &http.Client{Timeout: DefaultRequestTimeout},
+		UserAgent:    DefaultUserAgent,
+		MaxRetries:   DefaultMaxRetries,
+		MaxRetryWait: DefaultMaxRetryWait,
+		PageSize:     DefaultPageSize,
+		limiter:      newRateLimiter(0),
+	}
+	c.Assets = &AssetsService{client: c}
+	c.AssetTypes = &AssetTypesService{client: c}
+
+	return c, nil
+}
+
+// NewRequest creates a new HTTP request with proper authentication. A read only
+// client returns an error matching ErrReadOnly for methods other than GET.
+func (c *Client) NewRequest(ctx context.Context, method, endpoint string, body io.Reader) (*http.Request, error) {
+	if c.ReadOnly && method != http.MethodGet {
+		return nil, fmt.Errorf("refusing to send %s %s: %w", method, endpoint, ErrReadOnly)
+	}
+
+	url := fmt.Sprintf("%s%s", c.BaseURL, endpoint)
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set authentication using Basic Auth with API key as username and "X" as password
+	req.SetBasicAuth(c.APIKey, "X")
+
+	// Set required headers
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.UserAgent)
+
+	return req, nil
+}
+
+// SetRequestsPerMinute limits the requests sent to the API by all operations together.
+// A limit of 0 only holds requests back when the API rejects them for exceeding the
+// rate limit of the account.
+func (c *Client) SetRequestsPerMinute(requestsPerMinute int) {
+	c.limiter = newRateLimiter(requestsPerMinute)
+}
+
+// Do executes an HTTP request and returns the response. Responses with an error
+// status are returned as an *APIError, which matches ErrNotFound for 404.
+// Rate limited requests are retried for every method, while server errors and
+// network failures are only retried for idempotent methods. Requests wait for the
+// client side rate limit first, and a rate limited request or a response reporting
+// that the quota is used up holds back all others.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	var resp *http.Response
+	var err error
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			if err := rewindRequestBody(req); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := c.waitForRateLimit(ctx); err != nil {
+			return nil, fmt.Errorf("failed to execute request: %w", err)
+		}
+
+		c.logRequest(ctx, req, attempt)
+		resp, err = c.HTTPClient.Do(req)
+		if err == nil {
+			c.logResponse(ctx, req, resp)
+
+			// A response that used up the quota holds back the next request, instead of
+			// letting it run into a 429
+			if reset, ok := c.rateLimitReset(resp); ok && resp.StatusCode != http.StatusTooManyRequests {
+				c.limiter.pause(reset)
+			}
+		}
+
+		rateLimited := err == nil && resp.StatusCode == http.StatusTooManyRequests
+		retry := shouldRetry(req, resp, err) && attempt < c.MaxRetries
+		if !rateLimited && !retry {
+			break
+		}
+
+		wait := c.retryDelay(resp, attempt)
+		if rateLimited {
+			// The rate limit applies to the whole account, so every other request would be rejected too
+			c.limiter.pause(wait)
+		}
+		if !retry {
+			break
+		}
+
+		fields := map[string]interface{}{
+			"http_method":  req.Method,
+			"http_url":     req.URL.String(),
+			"http_attempt": attempt + 1,
+			"max_retries":  c.MaxRetries,
+			"retry_in":     wait.String(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+			logWarn(ctx, c.Logger, "API request failed, retrying", fields)
+		} else {
+			fields["http_status_code"] = resp.StatusCode
+			logWarn(ctx, c.Logger, "API request was rejected, retrying", fields)
+			drainResponse(resp)
+		}
+
+		if err := sleepContext(req.Context(), wait); err != nil {
+			return nil, fmt.Errorf("failed to execute request: %w", err)
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+
+	// Decode the error payload of failed requests
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		return nil, newAPIError(resp)
+	}
+
+	return resp, nil
+}
+
+// do sends a request with body encoded as JSON, when it is not nil, and decodes the
+// response into v, when it is not nil
+func (c *Client) do(ctx context.Context, method, endpoint string, body, v interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		jsonData, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(jsonData)
+	}
+
+	req, err := c.NewRequest(ctx, method, endpoint, reader)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if v == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response for %s %s: %w", method, endpoint, err)
+	}
+
+	return nil
+}
+
+// waitForRateLimit blocks until the rate limiter lets the next request through
+func (c *Client) waitForRateLimit(ctx context.Context) error {
+	start := time.Now()
+	if err := c.limiter.wait(ctx); err != nil {
+		return err
+	}
+	if waited := time.Since(start); waited >= time.Millisecond {
+		logDebug(ctx, c.Logger, "Waited for the rate limit", map[string]interface{}{
+			"waited": waited.String(),
+		})
+	}
+	return nil
+}
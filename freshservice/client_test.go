@@ -0,0 +1,36 @@
+package freshservice
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestClient_readOnly(t *testing.T) {
+	var methods []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{
+			"asset": map[string]interface{}{"display_id": 42, "name": "Laptop 1"},
+		})
+	})
+	client.ReadOnly = true
+	ctx := context.Background()
+
+	if _, err := client.Assets.Get(ctx, 42); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := client.Assets.Update(ctx, 42, &AssetRequest{Name: "Laptop 2"})
+	if !errors.Is(err, ErrReadOnly) {
+		t.Errorf("expected the update to be refused, got %v", err)
+	}
+	if err := client.Assets.Delete(ctx, 42); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("expected the delete to be refused, got %v", err)
+	}
+
+	if len(methods) != 1 || methods[0] != http.MethodGet {
+		t.Errorf("expected only the GET request to be sent, got %v", methods)
+	}
+}
@@ -0,0 +1,26 @@
+// Package freshservice is a client for the assets and asset types of the
+// Freshservice API v2. The Terraform provider is built on it, but it can be used
+// on its own:
+//
+//	client, err := freshservice.NewClient(apiKey, "example.freshservice.com", "")
+//	if err != nil {
+//		return err
+//	}
+//
+//	asset, err := client.Assets.Get(ctx, 42)
+//	if errors.Is(err, freshservice.ErrNotFound) {
+//		// The asset was deleted
+//	}
+//
+//	for asset, err := range client.Assets.Search(ctx, "name:'Laptop 1'") {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(asset.DisplayID, asset.Name)
+//	}
+//
+// Requests are retried when they are rate limited, and on server errors when they
+// are idempotent. Failed requests return an *APIError with the status code and the
+// field errors reported by the API. Nothing is logged unless Client.Logger is set,
+// e.g. to an adapter of the application's logger.
+package freshservice
@@ -0,0 +1,112 @@
+package freshservice
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// DefaultAPIPath is the path of the Freshservice API below the domain
+const DefaultAPIPath = "/api/v2"
+
+// ErrDomainIsURL is returned by NormalizeDomain for URLs, which belong in the base URL instead
+var ErrDomainIsURL = errors.New("not a URL")
+
+var (
+	// domainLabelRegexp matches a single label of a host name
+	domainLabelRegexp = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+	// freshserviceDomainRegexp matches Freshservice domains of the US, EU, India and
+	// Australia regions, e.g. acme.freshservice.com or acme.freshservice.com.au
+	freshserviceDomainRegexp = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?\.freshservice\.(com|eu|in|com\.au)$`)
+	// freshserviceRootRegexp matches the Freshservice domains without an account name
+	freshserviceRootRegexp = regexp.MustCompile(`^freshservice\.(com|eu|in|com\.au)$`)
+)
+
+// NormalizeDomain validates a Freshservice domain and returns its host name.
+// A bare account name such as "acme" becomes acme.freshservice.com. Other host
+// names, regional domains such as acme.freshservice.eu as well as custom domains
+// such as helpdesk.acme.com, are kept as they are.
+func NormalizeDomain(domain string) (string, error) {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+
+	if domain == "" {
+		return "", fmt.Errorf("domain is required")
+	}
+	if strings.Contains(domain, "://") || strings.ContainsAny(domain, "/:?#@") {
+		return "", fmt.Errorf("domain %q must be a host name such as \"acme\" or \"acme.freshservice.com\", %w", domain, ErrDomainIsURL)
+	}
+
+	if !strings.Contains(domain, ".") {
+		if !domainLabelRegexp.MatchString(domain) {
+			return "", fmt.Errorf("domain %q is not a valid Freshservice account name; it may only contain letters, digits and hyphens", domain)
+		}
+		return domain + ".freshservice.com", nil
+	}
+
+	if freshserviceRootRegexp.MatchString(domain) {
+		return "", fmt.Errorf("domain %q is missing the account name; expected e.g. \"acme.%s\"", domain, domain)
+	}
+	if len(domain) > 253 {
+		return "", fmt.Errorf("domain %q is not a valid host name; it is longer than 253 characters", domain)
+	}
+	for _, label := range strings.Split(domain, ".") {
+		if len(label) > 63 || !domainLabelRegexp.MatchString(label) {
+			return "", fmt.Errorf("domain %q is not a valid host name; expected \"acme\", \"acme.freshservice.com\" or a regional domain such as \"acme.freshservice.eu\"", domain)
+		}
+	}
+
+	return domain, nil
+}
+
+// IsFreshserviceDomain reports whether a host name returned by NormalizeDomain is a
+// domain of Freshservice, of any region, rather than a custom domain of an account
+func IsFreshserviceDomain(host string) bool {
+	return freshserviceDomainRegexp.MatchString(host)
+}
+
+// NormalizeBaseURL validates the base URL of the API and returns it without a
+// trailing slash. URLs without a path get the default API path. Plain http is
+// only accepted for localhost, so API keys are never sent unencrypted over a network.
+func NormalizeBaseURL(baseURL string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(baseURL))
+	if err != nil {
+		return "", fmt.Errorf("base URL %q is not a valid URL: %w", baseURL, err)
+	}
+
+	if u.Host == "" {
+		return "", fmt.Errorf("base URL %q must be an absolute URL such as \"https://acme.freshservice.com/api/v2\"", baseURL)
+	}
+	if u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("base URL %q must not contain credentials, a query or a fragment", baseURL)
+	}
+
+	switch u.Scheme {
+	case "https":
+	case "http":
+		if !isLoopbackHost(u.Hostname()) {
+			return "", fmt.Errorf("base URL %q must use https; http is only allowed for localhost", baseURL)
+		}
+	default:
+		return "", fmt.Errorf("base URL %q must use https", baseURL)
+	}
+
+	u.Path = strings.TrimRight(u.Path, "/")
+	if u.Path == "" {
+		u.Path = DefaultAPIPath
+	}
+	u.RawPath = ""
+
+	return u.String(), nil
+}
+
+// isLoopbackHost reports whether host is localhost or a loopback address
+func isLoopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
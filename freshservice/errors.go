@@ -0,0 +1,104 @@
+package freshservice
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBodySize limits how much of an error response is read
+const maxErrorBodySize = 1 << 20
+
+// ErrNotFound is matched by errors for objects that do not exist, or are in the trash
+var ErrNotFound = errors.New("not found")
+
+// ErrReadOnly is matched by errors for requests a read only client refuses to send
+var ErrReadOnly = errors.New("client is read only")
+
+// APIFieldError represents a single field level error returned by the Freshservice API
+type APIFieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+	Code    string `json:"code"`
+}
+
+// APIError represents an error response returned by the Freshservice API
+type APIError struct {
+	StatusCode  int             `json:"-"`
+	Status      string          `json:"-"`
+	Method      string          `json:"-"`
+	Path        string          `json:"-"`
+	Body        string          `json:"-"`
+	Description string          `json:"description"`
+	Code        string          `json:"code"`
+	Message     string          `json:"message"`
+	Errors      []APIFieldError `json:"errors"`
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "API request failed with status %d", e.StatusCode)
+	if e.Method != "" {
+		fmt.Fprintf(&b, " (%s %s)", e.Method, e.Path)
+	}
+
+	switch {
+	case e.Description != "":
+		fmt.Fprintf(&b, ": %s", e.Description)
+	case e.Message != "":
+		fmt.Fprintf(&b, ": %s", e.Message)
+	case e.Status != "":
+		fmt.Fprintf(&b, ": %s", e.Status)
+	}
+
+	for _, fieldErr := range e.Errors {
+		fmt.Fprintf(&b, "; %s", fieldErr.String())
+	}
+
+	return b.String()
+}
+
+// Is reports whether the error matches target, so errors.Is(err, ErrNotFound)
+// holds for 404 responses
+func (e *APIError) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
+// String formats a field error for messages
+func (e APIFieldError) String() string {
+	msg := e.Message
+	if e.Field != "" {
+		msg = fmt.Sprintf("%s: %s", e.Field, msg)
+	}
+	if e.Code != "" {
+		msg = fmt.Sprintf("%s (%s)", msg, e.Code)
+	}
+	return msg
+}
+
+// newAPIError builds an APIError from a failed response, decoding the error payload when present
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+	}
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.Path = resp.Request.URL.Path
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil || len(body) == 0 {
+		return apiErr
+	}
+	apiErr.Body = string(body)
+
+	// The body is not always JSON (e.g. HTML from a proxy), so decoding errors are ignored
+	_ = json.Unmarshal(body, apiErr)
+
+	return apiErr
+}
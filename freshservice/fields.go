@@ -0,0 +1,195 @@
+package freshservice
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Data types of asset type fields as reported by the API
+const (
+	FieldTypeText      = "text"
+	FieldTypeParagraph = "paragraph"
+	FieldTypeDropdown  = "dropdown"
+	FieldTypeNumber    = "number"
+	FieldTypeDecimal   = "decimal"
+	FieldTypeDate      = "date"
+	FieldTypeCheckbox  = "checkbox"
+	FieldTypeBoolean   = "boolean"
+)
+
+// assetTypeIDSuffix matches the asset type ID appended to custom field names
+var assetTypeIDSuffix = regexp.MustCompile(`_\d+$`)
+
+// AssetTypeField represents a field definition of an asset type
+type AssetTypeField struct {
+	ID           int           `json:"id"`
+	AssetTypeID  *int          `json:"asset_type_id"`
+	Name         string        `json:"name"`
+	Label        string        `json:"label"`
+	Description  string        `json:"desc"`
+	DataType     string        `json:"data_type"`
+	Mandatory    bool          `json:"mandatory"`
+	DefaultField bool          `json:"default_field"`
+	Choices      []interface{} `json:"choices"`
+}
+
+// AssetTypeFieldGroup represents a section of fields shown together for an asset type
+type AssetTypeFieldGroup struct {
+	ID          int              `json:"id"`
+	FieldHeader string           `json:"field_header"`
+	Fields      []AssetTypeField `json:"fields"`
+}
+
+// AssetTypeFieldRequest represents the request body for asset type field operations
+type AssetTypeFieldRequest struct {
+	Label       string   `json:"label"`
+	Description string   `json:"desc"`
+	DataType    string   `json:"data_type,omitempty"`
+	Mandatory   bool     `json:"mandatory"`
+	Choices     []string `json:"choices,omitempty"`
+}
+
+// ShortName returns the field name without the asset type ID suffix
+func (f *AssetTypeField) ShortName() string {
+	return assetTypeIDSuffix.ReplaceAllString(f.Name, "")
+}
+
+// ChoiceValues returns the allowed values of a dropdown field. Choices are sent
+// either as plain strings or as [value, id] pairs.
+func (f *AssetTypeField) ChoiceValues() []string {
+	var values []string
+	for _, choice := range f.Choices {
+		switch c := choice.(type) {
+		case string:
+			values = append(values, c)
+		case []interface{}:
+			if len(c) > 0 {
+				if value, ok := c[0].(string); ok {
+					values = append(values, value)
+				}
+			}
+		case map[string]interface{}:
+			if value, ok := c["value"].(string); ok {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// ConvertValue converts a configured string value to the JSON type the API expects for the field
+func (f *AssetTypeField) ConvertValue(value string) (interface{}, error) {
+	switch f.DataType {
+	case FieldTypeNumber:
+		n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a valid whole number", value)
+		}
+		return n, nil
+	case FieldTypeDecimal:
+		n, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a valid decimal number", value)
+		}
+		return n, nil
+	case FieldTypeCheckbox, FieldTypeBoolean:
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a valid boolean", value)
+		}
+		return b, nil
+	case FieldTypeDate:
+		if !isValidFieldDate(value) {
+			return nil, fmt.Errorf("%q is not a valid date, expected YYYY-MM-DD or RFC 3339", value)
+		}
+		return value, nil
+	case FieldTypeDropdown:
+		if choices := f.ChoiceValues(); len(choices) > 0 && !containsString(choices, value) {
+			return nil, fmt.Errorf("%q is not one of the allowed values: %s", value, strings.Join(choices, ", "))
+		}
+		return value, nil
+	}
+
+	// Text, paragraph and any other type are sent exactly as configured
+	return value, nil
+}
+
+// FormatValue converts a value returned by the API back to the string stored in state
+func (f *AssetTypeField) FormatValue(value interface{}) string {
+	return FormatFieldValue(value)
+}
+
+// Equivalent reports whether a configured value and a value returned by the API are
+// the same for the field's data type, e.g. "1.50" and 1.5 for a decimal field
+func (f *AssetTypeField) Equivalent(configured string, value interface{}) bool {
+	formatted := f.FormatValue(value)
+	if configured == formatted {
+		return true
+	}
+
+	switch f.DataType {
+	case FieldTypeNumber, FieldTypeDecimal:
+		a, errA := strconv.ParseFloat(strings.TrimSpace(configured), 64)
+		b, errB := strconv.ParseFloat(formatted, 64)
+		return errA == nil && errB == nil && a == b
+	case FieldTypeCheckbox, FieldTypeBoolean:
+		a, errA := strconv.ParseBool(strings.TrimSpace(configured))
+		b, errB := strconv.ParseBool(formatted)
+		return errA == nil && errB == nil && a == b
+	case FieldTypeDate:
+		a, okA := parseFieldDate(configured)
+		b, okB := parseFieldDate(formatted)
+		return okA && okB && a.Equal(b)
+	}
+
+	// Text values must match exactly
+	return false
+}
+
+// FormatFieldValue converts a type field value returned by the API to a string
+// without losing precision or adding formatting, so strings round-trip exactly
+func FormatFieldValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case json.Number:
+		return v.String()
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// isValidFieldDate reports whether a value is a date the API accepts
+func isValidFieldDate(value string) bool {
+	_, ok := parseFieldDate(value)
+	return ok
+}
+
+// parseFieldDate parses a date field value given as YYYY-MM-DD or RFC 3339
+func parseFieldDate(value string) (time.Time, bool) {
+	for _, layout := range []string{"2006-01-02", time.RFC3339} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+package freshservice
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// redactedValue replaces sensitive values in logs
+	redactedValue = "***"
+
+	// maxLoggedBodySize limits how much of a request or response body is logged
+	maxLoggedBodySize = 64 << 10
+)
+
+// sensitiveHeaders are request and response headers never written to logs
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// Logger receives the log messages of a client. Fields hold structured details, such
+// as the method and URL of a request. Credentials in headers and the values of
+// SensitiveTypeFields are redacted before they reach the logger, but callers that
+// know the API key should still mask it as a last resort.
+type Logger interface {
+	Debug(ctx context.Context, msg string, fields map[string]interface{})
+	Warn(ctx context.Context, msg string, fields map[string]interface{})
+}
+
+// logDebug logs a debug message to logger, when it is set
+func logDebug(ctx context.Context, logger Logger, msg string, fields map[string]interface{}) {
+	if logger != nil {
+		logger.Debug(ctx, msg, fields)
+	}
+}
+
+// logWarn logs a warning to logger, when it is set
+func logWarn(ctx context.Context, logger Logger, msg string, fields map[string]interface{}) {
+	if logger != nil {
+		logger.Warn(ctx, msg, fields)
+	}
+}
+
+// logRequest logs a request with its headers and body, redacting credentials
+// and sensitive type fields
+func (c *Client) logRequest(ctx context.Context, req *http.Request, attempt int) {
+	if c.Logger == nil {
+		return
+	}
+
+	fields := map[string]interface{}{
+		"http_method":          req.Method,
+		"http_url":             req.URL.String(),
+		"http_attempt":         attempt + 1,
+		"http_request_headers": redactHeaders(req.Header),
+	}
+
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(io.LimitReader(body, maxLoggedBodySize))
+			body.Close()
+			if len(data) > 0 {
+				fields["http_request_body"] = c.redactBody(data)
+			}
+		}
+	}
+
+	logDebug(ctx, c.Logger, "Sending API request", fields)
+}
+
+// logResponse logs a response with its headers and the start of its body, which is
+// put back so the caller still reads the whole body. Without a logger the body is left alone.
+func (c *Client) logResponse(ctx context.Context, req *http.Request, resp *http.Response) {
+	if c.Logger == nil {
+		return
+	}
+
+	fields := map[string]interface{}{
+		"http_method":           req.Method,
+		"http_url":              req.URL.String(),
+		"http_status_code":      resp.StatusCode,
+		"http_response_headers": redactHeaders(resp.Header),
+	}
+
+	if resp.Body != nil {
+		// Only the logged part is buffered, the rest is still streamed from the connection
+		data, err := io.ReadAll(io.LimitReader(resp.Body, maxLoggedBodySize))
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(data), resp.Body), resp.Body}
+		if err != nil {
+			fields["http_response_body_error"] = err.Error()
+		} else if len(data) > 0 {
+			fields["http_response_body"] = c.redactBody(data)
+		}
+	}
+
+	logDebug(ctx, c.Logger, "Received API response", fields)
+}
+
+// redactHeaders returns the headers as a map for logging, with credentials replaced
+func redactHeaders(header http.Header) map[string]string {
+	redacted := make(map[string]string, len(header))
+	for key, values := range header {
+		redacted[key] = strings.Join(values, ", ")
+	}
+	for _, key := range sensitiveHeaders {
+		if _, ok := redacted[http.CanonicalHeaderKey(key)]; ok {
+			redacted[http.CanonicalHeaderKey(key)] = redactedValue
+		}
+	}
+	return redacted
+}
+
+// redactBody returns a JSON body for logging, with the values of sensitive type fields
+// replaced. Bodies that are not JSON are logged as they are.
+func (c *Client) redactBody(data []byte) string {
+	if len(c.SensitiveTypeFields) == 0 {
+		return string(data)
+	}
+
+	var body interface{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return string(data)
+	}
+
+	redacted, err := json.Marshal(c.redactTypeFields(body, false))
+	if err != nil {
+		return string(data)
+	}
+	return string(redacted)
+}
+
+// redactTypeFields walks a decoded JSON value and replaces the values of sensitive
+// type fields, wherever type_fields objects appear (e.g. in single assets and in lists)
+func (c *Client) redactTypeFields(value interface{}, inTypeFields bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if inTypeFields {
+				if c.SensitiveTypeFields[assetTypeIDSuffix.ReplaceAllString(key, "")] && item != nil {
+					v[key] = redactedValue
+				}
+				continue
+			}
+			v[key] = c.redactTypeFields(item, key == "type_fields")
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = c.redactTypeFields(item, false)
+		}
+	}
+	return value
+}
@@ -0,0 +1,265 @@
+package freshservice
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testAPIKey = "test-api-key"
+
+// recordingLogger is a Logger keeping the messages logged to it
+type recordingLogger struct {
+	entries []recordedEntry
+}
+
+// recordedEntry is a message logged to a recordingLogger
+type recordedEntry struct {
+	level  string
+	msg    string
+	fields map[string]interface{}
+}
+
+func (l *recordingLogger) Debug(ctx context.Context, msg string, fields map[string]interface{}) {
+	l.entries = append(l.entries, recordedEntry{level: "debug", msg: msg, fields: fields})
+}
+
+func (l *recordingLogger) Warn(ctx context.Context, msg string, fields map[string]interface{}) {
+	l.entries = append(l.entries, recordedEntry{level: "warn", msg: msg, fields: fields})
+}
+
+func TestDo_logsRedacted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"asset":` + string(body) + `}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(testAPIKey, "", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SensitiveTypeFields = map[string]bool{"serial": true}
+	logger := &recordingLogger{}
+	client.Logger = logger
+
+	body := `{"name":"Laptop 1","asset_type_id":26,"type_fields":{"serial_26":"SN-SECRET-1"}}`
+	req, err := client.NewRequest(context.Background(), "POST", "/assets", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	// The response body is still readable after being logged
+	var created bytes.Buffer
+	if _, err := created.ReadFrom(resp.Body); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(created.String(), "SN-SECRET-1") {
+		t.Fatalf("expected the response body to be readable, got %s", created.String())
+	}
+
+	var messages []string
+	for _, entry := range logger.entries {
+		messages = append(messages, entry.level+": "+entry.msg)
+		logged := fmt.Sprint(entry.fields)
+		for _, secret := range []string{testAPIKey, "SN-SECRET-1"} {
+			if strings.Contains(logged, secret) {
+				t.Errorf("expected %q to be redacted in %q, got %s", secret, entry.msg, logged)
+			}
+		}
+	}
+	if strings.Join(messages, ",") != "debug: Sending API request,debug: Received API response" {
+		t.Fatalf("unexpected log messages %v", messages)
+	}
+}
+
+func TestDo_logsLargeResponse(t *testing.T) {
+	large := `"` + strings.Repeat("x", 2*maxLoggedBodySize) + `"`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(large))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(testAPIKey, "", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := &recordingLogger{}
+	client.Logger = logger
+
+	req, err := client.NewRequest(context.Background(), "GET", "/assets", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	// Only the start of the body is logged, and the caller still reads all of it
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != large {
+		t.Fatalf("expected the whole body to be readable, got %d bytes", len(body))
+	}
+	for _, entry := range logger.entries {
+		if logged, ok := entry.fields["http_response_body"].(string); ok && len(logged) > maxLoggedBodySize {
+			t.Errorf("expected at most %d bytes of the body to be logged, got %d", maxLoggedBodySize, len(logged))
+		}
+	}
+}
+
+func TestDo_withoutLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(testAPIKey, "", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := client.NewRequest(context.Background(), "GET", "/assets", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+}
+
+func TestDo_withoutLoggerStreamsBody(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("["))
+		w.(http.Flusher).Flush()
+		<-release
+		w.Write([]byte("]"))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client, err := NewClient(testAPIKey, "", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := client.NewRequest(context.Background(), "GET", "/assets", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The response is returned before its body is complete, so nothing buffered it
+	done := make(chan *http.Response, 1)
+	go func() {
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Error(err)
+		}
+		done <- resp
+	}()
+
+	select {
+	case resp := <-done:
+		if resp != nil {
+			resp.Body.Close()
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the response body not to be read without a logger")
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Set("Authorization", "Basic c2VjcmV0Olg=")
+	header.Set("Proxy-Authorization", "Basic cHJveHk6c2VjcmV0")
+	header.Add("Set-Cookie", "session=1")
+	header.Add("Set-Cookie", "tracking=2")
+	header.Set("Content-Type", "application/json")
+
+	redacted := redactHeaders(header)
+
+	expected := map[string]string{
+		"Authorization":       redactedValue,
+		"Proxy-Authorization": redactedValue,
+		"Set-Cookie":          redactedValue,
+		"Content-Type":        "application/json",
+	}
+	if len(redacted) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, redacted)
+	}
+	for key, value := range expected {
+		if redacted[key] != value {
+			t.Errorf("expected %s to be %q, got %q", key, value, redacted[key])
+		}
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	client := &Client{SensitiveTypeFields: map[string]bool{"serial": true, "account_id": true}}
+
+	testCases := []struct {
+		name     string
+		client   *Client
+		body     string
+		expected string
+	}{
+		{
+			name:     "single asset",
+			client:   client,
+			body:     `{"asset":{"name":"serial","type_fields":{"serial_26":"SN-1","state_26":"In Use"}}}`,
+			expected: `{"asset":{"name":"serial","type_fields":{"serial_26":"***","state_26":"In Use"}}}`,
+		},
+		{
+			name:     "asset list",
+			client:   client,
+			body:     `{"assets":[{"type_fields":{"account_id_1":"123456789012"}},{"type_fields":{"account_id_1":null}}]}`,
+			expected: `{"assets":[{"type_fields":{"account_id_1":"***"}},{"type_fields":{"account_id_1":null}}]}`,
+		},
+		{
+			name:     "request without type fields",
+			client:   client,
+			body:     `{"workspace_id":3}`,
+			expected: `{"workspace_id":3}`,
+		},
+		{
+			name:     "not JSON",
+			client:   client,
+			body:     `Bad Gateway`,
+			expected: `Bad Gateway`,
+		},
+		{
+			name:     "no sensitive fields",
+			client:   &Client{},
+			body:     `{"asset":{"type_fields":{"serial_26":"SN-1"}}}`,
+			expected: `{"asset":{"type_fields":{"serial_26":"SN-1"}}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if redacted := tc.client.redactBody([]byte(tc.body)); redacted != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, redacted)
+			}
+		})
+	}
+}
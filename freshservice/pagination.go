@@ -0,0 +1,156 @@
+package freshservice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultPageSize is the number of items requested per page by default
+	DefaultPageSize = 100
+	// MaxPageSize is the largest per_page value accepted by the Freshservice API
+	MaxPageSize = 100
+	// maxPages guards against endpoints that keep returning the same page
+	maxPages = 1000
+)
+
+// paginate iterates over every item found under key in the pages of a list or search
+// endpoint, fetching pages as they are needed. It follows the Link rel="next" header
+// when the API sends one and otherwise keeps incrementing page until a short or
+// empty page. An error ends the iteration.
+func paginate[T any](ctx context.Context, c *Client, path string, query url.Values, key string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		pageSize := c.PageSize
+		if pageSize <= 0 || pageSize > MaxPageSize {
+			pageSize = DefaultPageSize
+		}
+
+		query := cloneQuery(query)
+		query.Set("per_page", strconv.Itoa(pageSize))
+		query.Set("page", "1")
+
+		for fetched := 0; fetched < maxPages; fetched++ {
+			pageItems, next, err := fetchPage[T](ctx, c, path+"?"+query.Encode(), key)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			logDebug(ctx, c.Logger, "Fetched page", map[string]interface{}{"endpoint": path, "page": query.Get("page"), "items": len(pageItems)})
+
+			for _, item := range pageItems {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			switch {
+			case len(pageItems) == 0:
+				return
+			case next != nil:
+				// The Link header is authoritative, keep its query but stay on our own base URL
+				query = next
+			case len(pageItems) < pageSize:
+				return
+			default:
+				page, _ := strconv.Atoi(query.Get("page"))
+				query.Set("page", strconv.Itoa(page+1))
+			}
+		}
+
+		var zero T
+		yield(zero, fmt.Errorf("stopped listing %s after %d pages", path, maxPages))
+	}
+}
+
+// Collect gathers every item of an iterator returned by the client, e.g.
+// Collect(client.Assets.List(ctx, nil)). It stops at the first error.
+func Collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// fetchPage requests a single page and decodes the items under key.
+// It also returns the query of the next page when the response has a Link header.
+func fetchPage[T any](ctx context.Context, c *Client, endpoint, key string) ([]T, url.Values, error) {
+	req, err := c.NewRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	var body map[string]json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	var items []T
+	if raw, ok := body[key]; ok {
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode %s: %w", key, err)
+		}
+	}
+
+	next, err := nextPageQuery(resp.Header.Get("Link"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return items, next, nil
+}
+
+// nextPageQuery extracts the query string of the rel="next" target from a Link header
+func nextPageQuery(header string) (url.Values, error) {
+	for _, link := range strings.Split(header, ",") {
+		parts := strings.Split(link, ";")
+		target := strings.TrimSpace(parts[0])
+		if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+
+		isNext := false
+		for _, param := range parts[1:] {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(name, "rel") && strings.Trim(value, `"`) == "next" {
+				isNext = true
+			}
+		}
+		if !isNext {
+			continue
+		}
+
+		nextURL, err := url.Parse(strings.Trim(target, "<>"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid next page link %q: %w", target, err)
+		}
+		return nextURL.Query(), nil
+	}
+
+	return nil, nil
+}
+
+// cloneQuery copies query values, so iterating twice starts from the same query
+func cloneQuery(query url.Values) url.Values {
+	clone := url.Values{}
+	for key, values := range query {
+		clone[key] = append([]string(nil), values...)
+	}
+	return clone
+}
@@ -0,0 +1,91 @@
+package freshservice
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimitBurst is how many requests may be sent back to back before the client
+// side rate limit spaces them out, matching Terraform's default parallelism
+const rateLimitBurst = 10
+
+// rateLimiter is a token bucket shared by every request of a provider instance, so
+// parallel operations of all resources and data sources together stay under the
+// rate limit of the account. It also holds every request back while the API has
+// asked the provider to back off.
+type rateLimiter struct {
+	mu sync.Mutex
+	// interval is the time it takes to earn one request, zero when there is no client side limit
+	interval time.Duration
+	// burst is the number of requests that can be saved up
+	burst int
+	// next is the time the bucket would be empty again, had every request been sent at the steady rate
+	next time.Time
+	// pausedUntil is when the API accepts requests again after rejecting one with 429
+	pausedUntil time.Time
+}
+
+// newRateLimiter creates a rate limiter allowing requestsPerMinute requests per minute.
+// With requestsPerMinute 0, requests are only held back when the API asks for it.
+func newRateLimiter(requestsPerMinute int) *rateLimiter {
+	l := &rateLimiter{}
+	if requestsPerMinute > 0 {
+		l.interval = time.Minute / time.Duration(requestsPerMinute)
+		l.burst = min(rateLimitBurst, requestsPerMinute)
+	}
+	return l
+}
+
+// wait blocks until a request may be sent or the context is cancelled
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	delay := l.reserve(time.Now())
+	if delay <= 0 {
+		return nil
+	}
+	return sleepContext(ctx, delay)
+}
+
+// reserve takes the next free slot for a request and returns how long after now it is.
+// Slots are handed out in order, so waiting requests are sent in the order they came in.
+func (l *rateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	at := now
+	if l.pausedUntil.After(at) {
+		at = l.pausedUntil
+	}
+
+	if l.interval > 0 {
+		// Up to burst requests may go ahead of the steady rate
+		if earliest := l.next.Add(-time.Duration(l.burst-1) * l.interval); earliest.After(at) {
+			at = earliest
+		}
+		if l.next.Before(at) {
+			l.next = at
+		}
+		l.next = l.next.Add(l.interval)
+	}
+
+	return at.Sub(now)
+}
+
+// pause holds back every request for the given duration, after the API rejected one
+// because the rate limit of the account was exceeded
+func (l *rateLimiter) pause(d time.Duration) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until := time.Now().Add(d); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
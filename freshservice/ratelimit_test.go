@@ -0,0 +1,163 @@
+package freshservice
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_reserve(t *testing.T) {
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	// 60 requests per minute earn one request per second, with a burst of 10
+	l := newRateLimiter(60)
+	for i := 0; i < rateLimitBurst; i++ {
+		if delay := l.reserve(now); delay != 0 {
+			t.Fatalf("expected request %d of the burst to go immediately, got %s", i+1, delay)
+		}
+	}
+	for i := 1; i <= 3; i++ {
+		if delay := l.reserve(now); delay != time.Duration(i)*time.Second {
+			t.Fatalf("expected request %d after the burst to wait %ds, got %s", i, i, delay)
+		}
+	}
+
+	// Unused requests are saved up again, up to the burst
+	later := now.Add(time.Hour)
+	for i := 0; i < rateLimitBurst; i++ {
+		if delay := l.reserve(later); delay != 0 {
+			t.Fatalf("expected request %d of the second burst to go immediately, got %s", i+1, delay)
+		}
+	}
+	if delay := l.reserve(later); delay != time.Second {
+		t.Fatalf("expected the request after the second burst to wait 1s, got %s", delay)
+	}
+
+	// The burst never exceeds the limit itself
+	l = newRateLimiter(2)
+	l.reserve(now)
+	l.reserve(now)
+	if delay := l.reserve(now); delay != 30*time.Second {
+		t.Fatalf("expected the third request to wait 30s, got %s", delay)
+	}
+}
+
+func TestRateLimiter_pause(t *testing.T) {
+	for _, requestsPerMinute := range []int{0, 6000} {
+		l := newRateLimiter(requestsPerMinute)
+		if delay := l.reserve(time.Now()); delay != 0 {
+			t.Fatalf("expected no delay before pausing, got %s", delay)
+		}
+
+		l.pause(time.Minute)
+		if delay := l.reserve(time.Now()); delay < 59*time.Second {
+			t.Errorf("expected requests to wait for the pause with %d requests per minute, got %s", requestsPerMinute, delay)
+		}
+
+		// A shorter pause doesn't cut a longer one short
+		l.pause(time.Second)
+		if delay := l.reserve(time.Now()); delay < 59*time.Second {
+			t.Errorf("expected the longer pause to be kept with %d requests per minute, got %s", requestsPerMinute, delay)
+		}
+	}
+}
+
+func TestRateLimiter_waitCancelled(t *testing.T) {
+	l := newRateLimiter(1)
+	if err := l.wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the wait to end with the context, got %v", err)
+	}
+}
+
+func TestDo_rateLimitedHoldsBackOtherRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/throttled" {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(testAPIKey, "", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.MaxRetries = 0
+	client.MaxRetryWait = 200 * time.Millisecond
+
+	req, err := client.NewRequest(context.Background(), "GET", "/throttled", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Do(req); err == nil {
+		t.Fatal("expected a rate limit error")
+	}
+
+	// Retry-After is capped at MaxRetryWait, and applies to the next request as well
+	start := time.Now()
+	req, err = client.NewRequest(context.Background(), "GET", "/ok", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("expected the request to wait for the rate limit, it took %s", elapsed)
+	}
+}
+
+func TestDo_exhaustedQuotaHoldsBackNextRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/last" {
+			w.Header().Set("X-Ratelimit-Remaining", "0")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(testAPIKey, "", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.MaxRetryWait = 200 * time.Millisecond
+
+	get := func(endpoint string) time.Duration {
+		t.Helper()
+		start := time.Now()
+		req, err := client.NewRequest(context.Background(), "GET", endpoint, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return time.Since(start)
+	}
+
+	if elapsed := get("/ok"); elapsed >= 150*time.Millisecond {
+		t.Fatalf("expected the first request to go immediately, it took %s", elapsed)
+	}
+	get("/last")
+
+	// The quota was used up by a successful response, so the next request waits for the window
+	if elapsed := get("/ok"); elapsed < 150*time.Millisecond {
+		t.Errorf("expected the request to wait for the rate limit window, it took %s", elapsed)
+	}
+}
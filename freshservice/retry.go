@@ -0,0 +1,165 @@
+package freshservice
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// retryBaseDelay is the first backoff step when the API gives no hint about how long to wait
+const retryBaseDelay = time.Second
+
+// rateLimitWindow is the period the rate limit of a Freshservice account applies to.
+// The API doesn't say when the window resets, so an exhausted quota waits for a whole window.
+const rateLimitWindow = time.Minute
+
+// maxBackoffShift caps the exponent of the backoff, so large attempt numbers can't
+// overflow the delay. 1s << 16 is already well above any sensible maximum wait.
+const maxBackoffShift = 16
+
+// shouldRetry reports whether a request should be attempted again based on its outcome
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	// Never retry once the caller has given up
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		return isIdempotentMethod(req.Method)
+	}
+
+	// Freshservice rejects rate limited requests before processing them, so any method can be retried
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	if resp.StatusCode >= 500 {
+		return isIdempotentMethod(req.Method)
+	}
+
+	return false
+}
+
+// isIdempotentMethod reports whether repeating a request with this method is safe
+func isIdempotentMethod(method string) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryDelay works out how long to wait before the next attempt.
+// Retry-After takes precedence, an exhausted X-Ratelimit-Remaining waits for the
+// rate limit window to reset and anything else falls back to exponential backoff.
+func (c *Client) retryDelay(resp *http.Response, attempt int) time.Duration {
+	var wait time.Duration
+
+	if resp != nil {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			wait = retryAfter
+		} else if reset, ok := c.rateLimitReset(resp); ok {
+			wait = reset
+		}
+	}
+
+	if wait == 0 {
+		backoff := retryBaseDelay << uint(min(max(attempt, 0), maxBackoffShift))
+		if c.MaxRetryWait > 0 && backoff > c.MaxRetryWait {
+			backoff = c.MaxRetryWait
+		}
+		// Add up to 50% jitter so parallel operations don't retry in lockstep
+		wait = backoff + time.Duration(rand.Int63n(int64(backoff)/2+1))
+	}
+
+	if c.MaxRetryWait > 0 && wait > c.MaxRetryWait {
+		wait = c.MaxRetryWait
+	}
+
+	return wait
+}
+
+// rateLimitReset reports how long to hold back requests when X-Ratelimit-Remaining
+// says the quota of the account is used up, capped at MaxRetryWait
+func (c *Client) rateLimitReset(resp *http.Response) (time.Duration, bool) {
+	remaining := strings.TrimSpace(resp.Header.Get("X-Ratelimit-Remaining"))
+	if remaining == "" {
+		return 0, false
+	}
+	if n, err := strconv.Atoi(remaining); err != nil || n > 0 {
+		return 0, false
+	}
+
+	wait := rateLimitWindow
+	if c.MaxRetryWait > 0 && wait > c.MaxRetryWait {
+		wait = c.MaxRetryWait
+	}
+	return wait, true
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// rewindRequestBody resets the request body so the request can be sent again
+func rewindRequestBody(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+	if req.GetBody == nil {
+		return fmt.Errorf("cannot retry %s %s: request body cannot be rewound", req.Method, req.URL.Path)
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return fmt.Errorf("failed to rewind request body: %w", err)
+	}
+	req.Body = body
+
+	return nil
+}
+
+// drainResponse discards and closes a response body so the connection can be reused
+func drainResponse(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	resp.Body.Close()
+}
+
+// sleepContext waits for the given duration or until the context is cancelled
+func sleepContext(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,32 @@
+package freshservice
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryDelay_backoff(t *testing.T) {
+	c := &Client{MaxRetryWait: DefaultMaxRetryWait}
+
+	for _, tc := range []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{0, time.Second, 1500 * time.Millisecond},
+		{2, 4 * time.Second, 6 * time.Second},
+		{6, DefaultMaxRetryWait, DefaultMaxRetryWait},
+		// Large attempt numbers must neither overflow nor panic
+		{34, DefaultMaxRetryWait, DefaultMaxRetryWait},
+		{1000, DefaultMaxRetryWait, DefaultMaxRetryWait},
+	} {
+		if wait := c.retryDelay(nil, tc.attempt); wait < tc.min || wait > tc.max {
+			t.Errorf("attempt %d: expected a wait between %s and %s, got %s", tc.attempt, tc.min, tc.max, wait)
+		}
+	}
+
+	// Without a maximum wait the backoff stops growing at the cap
+	c.MaxRetryWait = 0
+	if wait := c.retryDelay(nil, 1000); wait < retryBaseDelay<<maxBackoffShift {
+		t.Errorf("expected the capped backoff, got %s", wait)
+	}
+}
@@ -0,0 +1,83 @@
+package freshservice
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// DefaultRequestTimeout is how long a single request may take by default, including reading the response
+const DefaultRequestTimeout = 60 * time.Second
+
+// TransportOptions configures the HTTP client used to call the API
+type TransportOptions struct {
+	// RequestTimeout limits a single attempt of a request, retries get a fresh timeout
+	RequestTimeout time.Duration
+	// ProxyURL is the proxy all requests go through. When empty, the HTTP_PROXY,
+	// HTTPS_PROXY and NO_PROXY environment variables are used.
+	ProxyURL string
+	// CABundleFile is a PEM file of certificate authorities trusted in addition to the system ones
+	CABundleFile string
+	// InsecureSkipVerify disables TLS certificate verification, for testing only
+	InsecureSkipVerify bool
+	// Logger receives warnings about the transport. Nothing is logged when it is nil.
+	Logger Logger
+}
+
+// NewHTTPClient builds an HTTP client for the given transport options
+func NewHTTPClient(ctx context.Context, opts TransportOptions) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+
+	if opts.ProxyURL != "" {
+		proxyURL, err := url.Parse(opts.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("proxy URL %q is not a valid URL: %w", opts.ProxyURL, err)
+		}
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, fmt.Errorf("proxy URL %q must use http, https or socks5", opts.ProxyURL)
+		}
+		if proxyURL.Host == "" {
+			return nil, fmt.Errorf("proxy URL %q must include a host", opts.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if opts.CABundleFile != "" {
+		pem, err := os.ReadFile(opts.CABundleFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the CA bundle: %w", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			logWarn(ctx, opts.Logger, "Failed to load the system certificate pool, only trusting the CA bundle", map[string]interface{}{"ca_bundle_file": opts.CABundleFile, "error": err.Error()})
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA bundle %s does not contain any PEM encoded certificates", opts.CABundleFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if opts.InsecureSkipVerify {
+		logWarn(ctx, opts.Logger, "TLS certificate verification is disabled, only skip it for testing", nil)
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   opts.RequestTimeout,
+	}, nil
+}
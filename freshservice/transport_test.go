@@ -0,0 +1,121 @@
+package freshservice
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewHTTPClient_caBundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(bundle, certPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		opts    TransportOptions
+		wantErr string
+	}{
+		"system roots only": {opts: TransportOptions{}, wantErr: "certificate"},
+		"ca bundle":         {opts: TransportOptions{CABundleFile: bundle}},
+		"insecure":          {opts: TransportOptions{InsecureSkipVerify: true}},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			client, err := NewHTTPClient(context.Background(), tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := client.Get(server.URL)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected an error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+		})
+	}
+}
+
+func TestNewHTTPClient_proxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	client, err := NewHTTPClient(context.Background(), TransportOptions{ProxyURL: proxy.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := client.Get("http://acme.freshservice.invalid/api/v2/assets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if proxied != "http://acme.freshservice.invalid/api/v2/assets" {
+		t.Errorf("expected the request to go through the proxy, got %q", proxied)
+	}
+}
+
+func TestNewHTTPClient_timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client, err := NewHTTPClient(context.Background(), TransportOptions{RequestTimeout: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Get(server.URL); err == nil || !strings.Contains(err.Error(), "Timeout") {
+		t.Fatalf("expected a timeout, got %v", err)
+	}
+}
+
+func TestNewHTTPClient_invalidOptions(t *testing.T) {
+	empty := filepath.Join(t.TempDir(), "empty.pem")
+	if err := os.WriteFile(empty, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		opts    TransportOptions
+		wantErr string
+	}{
+		"proxy scheme":    {opts: TransportOptions{ProxyURL: "ftp://proxy.example.com"}, wantErr: "must use http, https or socks5"},
+		"proxy host":      {opts: TransportOptions{ProxyURL: "http://"}, wantErr: "must include a host"},
+		"missing bundle":  {opts: TransportOptions{CABundleFile: filepath.Join(t.TempDir(), "missing.pem")}, wantErr: "failed to read the CA bundle"},
+		"bundle contents": {opts: TransportOptions{CABundleFile: empty}, wantErr: "does not contain any PEM encoded certificates"},
+	}
+
+	for name, tc := range cases {
+		if _, err := NewHTTPClient(context.Background(), tc.opts); err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+			t.Errorf("%s: expected an error containing %q, got %v", name, tc.wantErr, err)
+		}
+	}
+}
@@ -0,0 +1,461 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// Acceptance tests run Terraform against the fake Freshservice API. They only run with
+// TF_ACC set, and need the Terraform CLI given by TF_ACC_TERRAFORM_PATH, e.g.
+//
+//	TF_ACC=1 TF_ACC_TERRAFORM_PATH=$(which terraform) go test ./provider -run TestAcc
+
+// testAccProtoV5ProviderFactories serves the muxed provider, as Terraform would run it
+var testAccProtoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+	"freshservice": func() (tfprotov5.ProviderServer, error) {
+		server, err := ProviderServer(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		return server(), nil
+	},
+}
+
+// testAccPreCheck requires a local Terraform CLI rather than letting the test download one
+func testAccPreCheck(t *testing.T) {
+	t.Helper()
+
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") == "" {
+		t.Fatal("TF_ACC_TERRAFORM_PATH must be set to the Terraform CLI acceptance tests run")
+	}
+}
+
+// testAccProviderConfig configures the provider against the fake
+func testAccProviderConfig(f *fakeFreshservice) string {
+	return fmt.Sprintf(`
+provider "freshservice" {
+  api_key        = %q
+  base_url       = %q
+  max_retry_wait = 1
+}
+`, testAPIKey, f.URL())
+}
+
+// testAccTestCase returns a test case against the fake checking that every resource is
+// destroyed afterwards
+func testAccTestCase(t *testing.T, f *fakeFreshservice, steps ...resource.TestStep) resource.TestCase {
+	return resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckDestroy(f),
+		Steps:                    steps,
+	}
+}
+
+// testAccCheckDestroy checks that asset types and fields in state are deleted, and that
+// assets are deleted or in the trash
+func testAccCheckDestroy(f *fakeFreshservice) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for address, rs := range s.RootModule().Resources {
+			if strings.HasPrefix(address, "data.") {
+				continue
+			}
+
+			switch rs.Type {
+			case "freshservice_asset_type":
+				id, _ := strconv.Atoi(rs.Primary.ID)
+				if f.AssetType(id) != nil {
+					return fmt.Errorf("%s still exists", address)
+				}
+			case "freshservice_asset_type_field":
+				assetTypeID, fieldID, _ := strings.Cut(rs.Primary.ID, "/")
+				id, _ := strconv.Atoi(assetTypeID)
+				field, _ := strconv.Atoi(fieldID)
+				if f.Field(id, field) != nil {
+					return fmt.Errorf("%s still exists", address)
+				}
+			default:
+				displayID, _ := strconv.Atoi(rs.Primary.ID)
+				if asset := f.Asset(displayID); asset != nil && asset["trashed"] != true {
+					return fmt.Errorf("%s still exists", address)
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// testAccStoreID stores the ID of a resource for the PreConfig of a later step
+func testAccStoreID(address string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[address]
+		if !ok {
+			return fmt.Errorf("%s not found in state", address)
+		}
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+// testAccExpectUpdate checks that a step updates the resource in place
+func testAccExpectUpdate(address string) resource.ConfigPlanChecks {
+	return resource.ConfigPlanChecks{
+		PreApply: []plancheck.PlanCheck{plancheck.ExpectResourceAction(address, plancheck.ResourceActionUpdate)},
+	}
+}
+
+func TestAccResourceAssetType(t *testing.T) {
+	f := newFakeFreshservice(t)
+	const address = "freshservice_asset_type.printer"
+	var id string
+
+	config := func(description string) string {
+		return testAccProviderConfig(f) + fmt.Sprintf(`
+resource "freshservice_asset_type" "printer" {
+  name                 = "Printer"
+  description          = %q
+  parent_asset_type_id = %d
+}
+`, description, testHardwareTypeID)
+	}
+
+	resource.Test(t, testAccTestCase(t, f,
+		resource.TestStep{
+			Config: config("Office printers"),
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr(address, "name", "Printer"),
+				resource.TestCheckResourceAttr(address, "parent_asset_type_id", strconv.Itoa(testHardwareTypeID)),
+				resource.TestCheckResourceAttr(address, "visible", "true"),
+				testAccStoreID(address, &id),
+			),
+		},
+		resource.TestStep{
+			Config:           config("Shared printers"),
+			ConfigPlanChecks: testAccExpectUpdate(address),
+			Check:            resource.TestCheckResourceAttr(address, "description", "Shared printers"),
+		},
+		resource.TestStep{
+			ResourceName:      address,
+			ImportState:       true,
+			ImportStateVerify: true,
+		},
+		resource.TestStep{
+			PreConfig: func() {
+				assetTypeID, _ := strconv.Atoi(id)
+				f.UpdateAssetType(assetTypeID, map[string]interface{}{"name": "Renamed in Freshservice"})
+			},
+			Config:           config("Shared printers"),
+			ConfigPlanChecks: testAccExpectUpdate(address),
+			Check:            resource.TestCheckResourceAttr(address, "name", "Printer"),
+		},
+	))
+}
+
+func TestAccResourceAssetTypeField(t *testing.T) {
+	f := newFakeFreshservice(t)
+	const address = "freshservice_asset_type_field.environment"
+	var id string
+
+	config := func(label string) string {
+		return testAccProviderConfig(f) + fmt.Sprintf(`
+resource "freshservice_asset_type" "cloud_account" {
+  name = "Cloud Account"
+}
+
+resource "freshservice_asset_type_field" "environment" {
+  asset_type_id = freshservice_asset_type.cloud_account.id
+  label         = %q
+  field_type    = "dropdown"
+  choices       = ["Production", "Staging"]
+}
+`, label)
+	}
+
+	resource.Test(t, testAccTestCase(t, f,
+		resource.TestStep{
+			Config: config("Environment"),
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr(address, "name", "environment"),
+				resource.TestCheckResourceAttr(address, "choices.#", "2"),
+				testAccStoreID(address, &id),
+			),
+		},
+		resource.TestStep{
+			Config:           config("Deployment Environment"),
+			ConfigPlanChecks: testAccExpectUpdate(address),
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr(address, "label", "Deployment Environment"),
+				resource.TestCheckResourceAttr(address, "name", "environment"),
+			),
+		},
+		resource.TestStep{
+			ResourceName:      address,
+			ImportState:       true,
+			ImportStateVerify: true,
+		},
+		resource.TestStep{
+			PreConfig: func() {
+				assetTypeID, fieldID, _ := strings.Cut(id, "/")
+				typeID, _ := strconv.Atoi(assetTypeID)
+				field, _ := strconv.Atoi(fieldID)
+				f.UpdateField(typeID, field, map[string]interface{}{"label": "Renamed in Freshservice"})
+			},
+			Config:           config("Deployment Environment"),
+			ConfigPlanChecks: testAccExpectUpdate(address),
+			Check:            resource.TestCheckResourceAttr(address, "label", "Deployment Environment"),
+		},
+	))
+}
+
+func TestAccResourceAsset(t *testing.T) {
+	f := newFakeFreshservice(t)
+	const address = "freshservice_asset.laptop"
+	var id string
+
+	config := func(name string) string {
+		return testAccProviderConfig(f) + fmt.Sprintf(`
+resource "freshservice_asset" "laptop" {
+  name          = %q
+  asset_type_id = %d
+  impact        = "medium"
+
+  type_fields = {
+    environment = "Production"
+    po_number   = "00123"
+  }
+}
+`, name, testHardwareTypeID)
+	}
+
+	resource.Test(t, testAccTestCase(t, f,
+		resource.TestStep{
+			Config: config("Dell Latitude 5520"),
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr(address, "name", "Dell Latitude 5520"),
+				resource.TestCheckResourceAttr(address, "type_fields.po_number", "00123"),
+				resource.TestCheckResourceAttrPair(address, "id", address, "display_id"),
+				testAccStoreID(address, &id),
+			),
+		},
+		resource.TestStep{
+			Config:           config("Dell Latitude 5530"),
+			ConfigPlanChecks: testAccExpectUpdate(address),
+			Check:            resource.TestCheckResourceAttr(address, "name", "Dell Latitude 5530"),
+		},
+		resource.TestStep{
+			ResourceName:      address,
+			ImportState:       true,
+			ImportStateVerify: true,
+		},
+		resource.TestStep{
+			PreConfig: func() {
+				displayID, _ := strconv.Atoi(id)
+				f.UpdateAsset(displayID, map[string]interface{}{"name": "Renamed in Freshservice"}, map[string]interface{}{"po_number_25": "999"})
+			},
+			Config:           config("Dell Latitude 5530"),
+			ConfigPlanChecks: testAccExpectUpdate(address),
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr(address, "name", "Dell Latitude 5530"),
+				resource.TestCheckResourceAttr(address, "type_fields.po_number", "00123"),
+			),
+		},
+	))
+}
+
+func TestAccResourceCloudAsset(t *testing.T) {
+	f := newFakeFreshservice(t)
+	const address = "freshservice_cloud_asset.production"
+	var id string
+
+	config := func(environment string) string {
+		return testAccProviderConfig(f) + fmt.Sprintf(`
+resource "freshservice_cloud_asset" "production" {
+  name          = "Production AWS Account"
+  asset_type_id = %d
+  impact        = "high"
+
+  type_fields = {
+    account_id  = "012345678901"
+    environment = %q
+  }
+}
+`, defaultAWSAccountTypeID, environment)
+	}
+
+	resource.Test(t, testAccTestCase(t, f,
+		resource.TestStep{
+			Config: config("Production"),
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr(address, "type_fields.account_id", "012345678901"),
+				testAccStoreID(address, &id),
+			),
+		},
+		resource.TestStep{
+			Config:           config("Staging"),
+			ConfigPlanChecks: testAccExpectUpdate(address),
+			Check:            resource.TestCheckResourceAttr(address, "type_fields.environment", "Staging"),
+		},
+		resource.TestStep{
+			ResourceName:      address,
+			ImportState:       true,
+			ImportStateVerify: true,
+		},
+		resource.TestStep{
+			PreConfig: func() {
+				displayID, _ := strconv.Atoi(id)
+				f.UpdateAsset(displayID, nil, map[string]interface{}{fmt.Sprintf("environment_%d", defaultAWSAccountTypeID): "Development"})
+			},
+			Config:           config("Staging"),
+			ConfigPlanChecks: testAccExpectUpdate(address),
+			Check:            resource.TestCheckResourceAttr(address, "type_fields.environment", "Staging"),
+		},
+	))
+}
+
+// testAccCloudAccountResource tests a resource of a cloud account asset type: create, an
+// update and drift of its environment, and import by display ID and natural key
+func testAccCloudAccountResource(t *testing.T, resourceType string, assetTypeID int, attributes, naturalKey string) {
+	f := newFakeFreshservice(t)
+	address := resourceType + ".production"
+	var id string
+
+	config := func(environment string) string {
+		return testAccProviderConfig(f) + fmt.Sprintf(`
+resource %q "production" {
+%s
+  environment = %q
+}
+`, resourceType, attributes, environment)
+	}
+
+	resource.Test(t, testAccTestCase(t, f,
+		resource.TestStep{
+			Config: config("Production"),
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr(address, "asset_type_id", strconv.Itoa(assetTypeID)),
+				resource.TestCheckResourceAttr(address, "environment", "Production"),
+				testAccStoreID(address, &id),
+			),
+		},
+		resource.TestStep{
+			Config:           config("Staging"),
+			ConfigPlanChecks: testAccExpectUpdate(address),
+			Check:            resource.TestCheckResourceAttr(address, "environment", "Staging"),
+		},
+		resource.TestStep{
+			ResourceName:      address,
+			ImportState:       true,
+			ImportStateVerify: true,
+		},
+		resource.TestStep{
+			ResourceName:      address,
+			ImportState:       true,
+			ImportStateId:     naturalKey,
+			ImportStateVerify: true,
+		},
+		resource.TestStep{
+			PreConfig: func() {
+				displayID, _ := strconv.Atoi(id)
+				f.UpdateAsset(displayID, nil, map[string]interface{}{fmt.Sprintf("environment_%d", assetTypeID): "Development"})
+			},
+			Config:           config("Staging"),
+			ConfigPlanChecks: testAccExpectUpdate(address),
+			Check:            resource.TestCheckResourceAttr(address, "environment", "Staging"),
+		},
+	))
+}
+
+func TestAccResourceAWSAccount(t *testing.T) {
+	testAccCloudAccountResource(t, "freshservice_aws_account", defaultAWSAccountTypeID, `
+  account_name = "Production AWS Account"
+  account_id   = "123456789012"
+  po_number    = "PO-2024-002"
+  owner        = "aws.admin@example.com"
+  approver     = "finance@example.com"
+  description  = "Main production AWS account"
+`, "account_id=123456789012")
+}
+
+func TestAccResourceAzureSubscription(t *testing.T) {
+	testAccCloudAccountResource(t, "freshservice_azure_subscription", defaultAzureSubscriptionTypeID, `
+  subscription_name = "Production Subscription"
+  subscription_id   = "12345678-1234-5678-9012-123456789012"
+  tenant_id         = "87654321-4321-8765-2109-876543210987"
+  po_number         = "PO-2024-001"
+  owner             = "john.doe@example.com"
+  approver          = "jane.smith@example.com"
+  description       = "Main production Azure subscription"
+`, "subscription_id=12345678-1234-5678-9012-123456789012")
+}
+
+func TestAccResourceGCPProject(t *testing.T) {
+	testAccCloudAccountResource(t, "freshservice_gcp_project", defaultGCPProjectTypeID, `
+  project_name = "my-production-project"
+  project_id   = "my-prod-project-123456"
+  po_number    = "PO-2024-003"
+  owner        = "gcp.admin@example.com"
+  approver     = "project.manager@example.com"
+  description  = "Main production GCP project"
+`, "project_id=my-prod-project-123456")
+}
+
+func TestAccDataSources(t *testing.T) {
+	f := newFakeFreshservice(t)
+	const address = "freshservice_asset.laptop"
+
+	config := testAccProviderConfig(f) + fmt.Sprintf(`
+resource "freshservice_asset" "laptop" {
+  name          = "Bob's laptop"
+  asset_type_id = %d
+}
+
+resource "freshservice_asset" "monitor" {
+  name          = "Bob's monitor"
+  asset_type_id = %d
+}
+
+data "freshservice_asset" "by_name" {
+  name = freshservice_asset.laptop.name
+}
+
+data "freshservice_asset" "by_display_id" {
+  display_id = freshservice_asset.laptop.display_id
+}
+
+data "freshservice_asset" "by_asset_tag" {
+  name      = freshservice_asset.laptop.name
+  asset_tag = freshservice_asset.laptop.asset_tag
+}
+
+data "freshservice_assets" "hardware" {
+  asset_type_id = freshservice_asset.laptop.asset_type_id
+  depends_on    = [freshservice_asset.monitor]
+}
+
+data "freshservice_asset_type" "hardware" {
+  name = "Hardware"
+}
+`, testHardwareTypeID, testHardwareTypeID)
+
+	resource.Test(t, testAccTestCase(t, f,
+		resource.TestStep{
+			Config: config,
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttrPair("data.freshservice_asset.by_name", "display_id", address, "display_id"),
+				resource.TestCheckResourceAttrPair("data.freshservice_asset.by_display_id", "name", address, "name"),
+				resource.TestCheckResourceAttrPair("data.freshservice_asset.by_asset_tag", "display_id", address, "display_id"),
+				resource.TestCheckResourceAttr("data.freshservice_assets.hardware", "assets.#", "2"),
+				resource.TestCheckResourceAttr("data.freshservice_asset_type.hardware", "id", strconv.Itoa(testHardwareTypeID)),
+			),
+		},
+	))
+}
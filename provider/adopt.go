@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lcp-llp/terraform-provider-freshservice/freshservice"
+)
+
+// assetAdoptExistingSchema returns the schema of adopt_existing, which makes a resource
+// take over the asset matching its natural key attribute instead of creating a new one
+func assetAdoptExistingSchema(key string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Description: fmt.Sprintf("When creating the resource, take over the existing asset with the same %s instead of creating a new one. Creating fails when several assets match (default: false)", key),
+	}
+}
+
+// adoptAssetByTypeField finds the asset a resource with adopt_existing takes over: the
+// active asset of its asset type whose type field holds the value of the attribute of the
+// same name. It returns nil when adopt_existing is not set or no asset matches.
+func adoptAssetByTypeField(ctx context.Context, d *schema.ResourceData, config *Config, assetTypeID int, field string) (*freshservice.Asset, diag.Diagnostics) {
+	if !d.Get("adopt_existing").(bool) {
+		return nil, nil
+	}
+	return adoptExistingAsset(ctx, config, assetTypeID, field, d.Get(field).(string))
+}
+
+// adoptAssetByTag finds the asset a resource with adopt_existing takes over: the active
+// asset of its asset type with the asset_tag of the resource. It returns nil when
+// adopt_existing is not set or no asset matches.
+func adoptAssetByTag(ctx context.Context, d *schema.ResourceData, config *Config) (*freshservice.Asset, diag.Diagnostics) {
+	if !d.Get("adopt_existing").(bool) {
+		return nil, nil
+	}
+
+	assetTag := d.Get("asset_tag").(string)
+	if assetTag == "" {
+		return nil, diag.Errorf("adopt_existing requires asset_tag to find the asset to take over")
+	}
+	return adoptExistingAsset(ctx, config, d.Get("asset_type_id").(int), naturalKeyAssetTag, assetTag)
+}
+
+// adoptExistingAsset returns the single asset whose natural key holds value, nil when none
+// does, and an error when several do, since there is no telling which one to take over
+func adoptExistingAsset(ctx context.Context, config *Config, assetTypeID int, key, value string) (*freshservice.Asset, diag.Diagnostics) {
+	matches, err := findAssetsByNaturalKey(ctx, config, assetTypeID, key, value)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	switch len(matches) {
+	case 0:
+		tflog.Debug(ctx, "No existing asset to adopt, creating one", map[string]interface{}{key: value})
+		return nil, nil
+	case 1:
+		tflog.Info(ctx, "Adopting existing asset", map[string]interface{}{key: value, "display_id": matches[0].DisplayID})
+		return &matches[0], nil
+	}
+
+	return nil, diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Cannot adopt an existing asset: %d assets have %s %q", len(matches), key, value),
+		Detail:   fmt.Sprintf("adopt_existing only takes over an asset when exactly one matches. The matching assets have display IDs %s. Remove the duplicates in Freshservice, or import the one to manage with terraform import.", assetDisplayIDs(matches)),
+	}}
+}
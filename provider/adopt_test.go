@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/lcp-llp/terraform-provider-freshservice/freshservice"
+)
+
+func TestAdoptExisting_naturalKey(t *testing.T) {
+	forEachAssetResource(t, func(t *testing.T, h *testHarness, name string, fixture assetResourceFixture) {
+		if fixture.key == "" {
+			t.Skip("adopted by asset_tag, see TestAdoptExisting_assetTag")
+		}
+
+		// The asset already exists, e.g. from discovery
+		existing := h.apply(name, nil, fixture.config)
+
+		config := fixture.config
+		config["adopt_existing"] = true
+		config["owner"] = "platform@example.com"
+
+		state := h.apply(name, nil, config)
+		if state.ID != existing.ID {
+			t.Fatalf("expected asset %s to be adopted, got %s", existing.ID, state.ID)
+		}
+		if n := h.fake.CountRequests("POST /api/v2/assets"); n != 1 {
+			t.Errorf("expected no asset to be created, got %d create requests", n)
+		}
+
+		// The adopted asset takes the configuration of the resource
+		requireAttributes(t, state, map[string]string{"owner": "platform@example.com"})
+		h.requireNoPlan(name, h.refresh(name, state), config)
+
+		// Without a match a new asset is created
+		config[fixture.key] = fixture.otherKey
+		state = h.apply(name, nil, config)
+		if state.ID == existing.ID {
+			t.Errorf("expected a new asset for %s %s", fixture.key, fixture.otherKey)
+		}
+	})
+}
+
+func TestAdoptExisting_duplicates(t *testing.T) {
+	h := newTestHarness(t)
+
+	config := map[string]interface{}{
+		"account_name": "Sandbox",
+		"account_id":   "210987654321",
+	}
+	first := h.apply("freshservice_aws_account", nil, config)
+	second := h.apply("freshservice_aws_account", nil, config)
+
+	config["adopt_existing"] = true
+	_, err := h.tryApply("freshservice_aws_account", nil, config)
+	if err == nil {
+		t.Fatal("expected adopting one of several matching assets to fail")
+	}
+	for _, want := range []string{`2 assets have account_id "210987654321"`, first.ID + ", " + second.ID} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected the error to contain %q, got %q", want, err)
+		}
+	}
+}
+
+func TestAdoptExisting_assetTag(t *testing.T) {
+	forEachAssetResource(t, func(t *testing.T, h *testHarness, name string, fixture assetResourceFixture) {
+		if fixture.key != "" {
+			t.Skip("adopted by " + fixture.key + ", see TestAdoptExisting_naturalKey")
+		}
+
+		existing, err := h.config.Assets.Create(context.Background(), &freshservice.AssetRequest{
+			Name:        "Laptop",
+			AssetTypeID: testHardwareTypeID,
+			AssetTag:    "LAP-0001",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Assets of other asset types are never adopted
+		if _, err := h.config.Assets.Create(context.Background(), &freshservice.AssetRequest{
+			Name:        "Laptop",
+			AssetTypeID: testLaptopTypeID,
+			AssetTag:    "LAP-0001",
+			TypeFields:  map[string]interface{}{"serial_26": "SN-0001"},
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		config := fixture.config
+		config["name"] = "Developer Laptop"
+		config["adopt_existing"] = true
+
+		// The asset tag is the natural key of generic assets
+		if _, err := h.tryApply(name, nil, config); err == nil || !strings.Contains(err.Error(), "requires asset_tag") {
+			t.Fatalf("expected adopt_existing to require asset_tag, got %v", err)
+		}
+
+		config["asset_tag"] = "LAP-0001"
+		state := h.apply(name, nil, config)
+		requireAttributes(t, state, map[string]string{
+			"id":        strconv.Itoa(existing.DisplayID),
+			"name":      "Developer Laptop",
+			"asset_tag": "LAP-0001",
+		})
+		h.requireNoPlan(name, h.refresh(name, state), config)
+	})
+}
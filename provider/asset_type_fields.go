@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/lcp-llp/terraform-provider-freshservice/freshservice"
+)
+
+// defaultAssetFields are built-in asset fields that are never sent as type_fields
+var defaultAssetFields = map[string]bool{
+	"name":          true,
+	"description":   true,
+	"asset_type_id": true,
+	"asset_tag":     true,
+	"impact":        true,
+	"usage_type":    true,
+	"user_id":       true,
+	"location_id":   true,
+	"department_id": true,
+	"agent_id":      true,
+	"group_id":      true,
+	"assigned_on":   true,
+}
+
+// assetTypeFieldSet holds the custom field definitions of an asset type
+type assetTypeFieldSet struct {
+	AssetTypeID int
+	Fields      []freshservice.AssetTypeField
+}
+
+// getAssetTypeFields returns the custom field definitions of an asset type.
+// Definitions are cached on the config so a plan only fetches each asset type once.
+func (c *Config) getAssetTypeFields(ctx context.Context, assetTypeID int) (*assetTypeFieldSet, error) {
+	c.fieldsMu.Lock()
+	defer c.fieldsMu.Unlock()
+
+	if fields, ok := c.assetTypeFields[assetTypeID]; ok {
+		return fields, nil
+	}
+
+	groups, err := c.AssetTypes.Fields(ctx, assetTypeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the fields of asset type %d: %w", assetTypeID, err)
+	}
+
+	fields := &assetTypeFieldSet{AssetTypeID: assetTypeID}
+	for _, group := range groups {
+		for _, field := range group.Fields {
+			if field.DefaultField || defaultAssetFields[field.Name] {
+				continue
+			}
+			fields.Fields = append(fields.Fields, field)
+		}
+	}
+
+	if c.assetTypeFields == nil {
+		c.assetTypeFields = make(map[int]*assetTypeFieldSet)
+	}
+	c.assetTypeFields[assetTypeID] = fields
+
+	return fields, nil
+}
+
+// invalidateAssetTypeFields drops the cached field definitions of an asset type
+// after its fields have been changed
+func (c *Config) invalidateAssetTypeFields(assetTypeID int) {
+	c.fieldsMu.Lock()
+	defer c.fieldsMu.Unlock()
+
+	delete(c.assetTypeFields, assetTypeID)
+}
+
+// Lookup finds the definition for a type_fields key. The key may be the full field
+// name or the name without its asset type ID suffix, which also covers fields
+// inherited from a parent asset type.
+func (s *assetTypeFieldSet) Lookup(key string) (*freshservice.AssetTypeField, bool) {
+	for i := range s.Fields {
+		if s.Fields[i].Name == key {
+			return &s.Fields[i], true
+		}
+	}
+
+	var match *freshservice.AssetTypeField
+	for i := range s.Fields {
+		if s.Fields[i].ShortName() != key {
+			continue
+		}
+		// Prefer the field defined on the asset type itself over inherited ones
+		if match == nil || s.Fields[i].Name == fmt.Sprintf("%s_%d", key, s.AssetTypeID) {
+			match = &s.Fields[i]
+		}
+	}
+
+	return match, match != nil
+}
+
+// Names returns the short names of all custom fields, sorted
+func (s *assetTypeFieldSet) Names() []string {
+	names := make([]string, 0, len(s.Fields))
+	for _, field := range s.Fields {
+		names = append(names, field.ShortName())
+	}
+	sort.Strings(names)
+	return names
+}
+
+// flattenAssetTypeFields converts type fields from the API to the strings stored in state.
+// Only the keys already in state are refreshed so unmanaged fields don't cause diffs;
+// on import, when nothing is in state yet, every field with a value is included.
+// Values equal to the configured value keep the configured formatting.
+func flattenAssetTypeFields(fields *assetTypeFieldSet, apiFields map[string]interface{}, current map[string]interface{}, importing bool) map[string]string {
+	typeFields := make(map[string]string)
+
+	if importing {
+		for _, field := range fields.Fields {
+			if value, ok := apiFields[field.Name]; ok && value != nil {
+				typeFields[field.ShortName()] = field.FormatValue(value)
+			}
+		}
+		return typeFields
+	}
+
+	for key, raw := range current {
+		field, ok := fields.Lookup(key)
+		if !ok {
+			// Fields without a definition are kept as sent, with the asset type ID suffix
+			if value, ok := apiFields[fmt.Sprintf("%s_%d", key, fields.AssetTypeID)]; ok && value != nil {
+				typeFields[key] = freshservice.FormatFieldValue(value)
+			}
+			continue
+		}
+		value, ok := apiFields[field.Name]
+		if !ok || value == nil {
+			continue
+		}
+
+		if configured, _ := raw.(string); field.Equivalent(configured, value) {
+			typeFields[key] = configured
+		} else {
+			typeFields[key] = field.FormatValue(value)
+		}
+	}
+
+	return typeFields
+}
@@ -1,85 +1,36 @@
 package provider
 
 import (
-	"context"
-	"fmt"
-	"io"
-	"net/http"
-	"strings"
+	"sync"
+
+	"github.com/lcp-llp/terraform-provider-freshservice/freshservice"
 )
 
 // Config holds the provider configuration
 type Config struct {
-	APIKey  string
-	Domain  string
-	BaseURL string
-	Client  *http.Client
-}
-
-// NewConfig creates a new configuration instance
-func NewConfig(apiKey, domain string) (*Config, error) {
-	if apiKey == "" {
-		return nil, fmt.Errorf("api_key is required")
-	}
-	if domain == "" {
-		return nil, fmt.Errorf("domain is required")
-	}
-
-	// Ensure domain has the correct format
-	if !strings.HasSuffix(domain, ".freshservice.com") {
-		if !strings.Contains(domain, ".") {
-			domain = domain + ".freshservice.com"
-		}
-	}
-
-	baseURL := fmt.Sprintf("https://%s/api/v2", domain)
-
-	config := &Config{
-		APIKey:  apiKey,
-		Domain:  domain,
-		BaseURL: baseURL,
-		Client:  &http.Client{},
-	}
+	// Client is the Freshservice API client shared by all resources and data sources
+	*freshservice.Client
 
-	return config, nil
-}
-
-// NewRequest creates a new HTTP request with proper authentication
-func (c *Config) NewRequest(ctx context.Context, method, endpoint string, body io.Reader) (*http.Request, error) {
-	url := fmt.Sprintf("%s%s", c.BaseURL, endpoint)
-
-	req, err := http.NewRequestWithContext(ctx, method, url, body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
+	// DefaultWorkspaceID is the workspace new assets are created in, unless they set their own
+	DefaultWorkspaceID int
 
-	// Set authentication using Basic Auth with API key as username and "X" as password
-	req.SetBasicAuth(c.APIKey, "X")
+	// Defaults are applied to every asset managed by the provider
+	Defaults assetDefaults
 
-	// Set required headers
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-
-	return req, nil
+	// fieldsMu guards assetTypeFields, the cache of asset type field definitions
+	fieldsMu        sync.Mutex
+	assetTypeFields map[int]*assetTypeFieldSet
 }
 
-// DoRequest executes an HTTP request and returns the response
-func (c *Config) DoRequest(req *http.Request) (*http.Response, error) {
-	resp, err := c.Client.Do(req)
+// NewConfig creates a new configuration instance. The API is reached at baseURL
+// when it is set, and at the API path of the domain otherwise.
+func NewConfig(apiKey, domain, baseURL string) (*Config, error) {
+	client, err := freshservice.NewClient(apiKey, domain, baseURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
-	}
-
-	// For 404 errors, return the response so caller can handle it
-	if resp.StatusCode == 404 {
-		return resp, nil
-	}
-
-	// Check for other API errors (but not 404)
-	if resp.StatusCode >= 400 {
-		defer resp.Body.Close()
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, resp.Status)
+		return nil, err
 	}
+	client.UserAgent = providerName + "/" + Version
+	client.Logger = httpLogger{apiKey: apiKey}
 
-	return resp, nil
+	return &Config{Client: client}, nil
 }
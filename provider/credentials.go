@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// APIKeyEnvVar is the environment variable holding the API key
+	APIKeyEnvVar = "FRESHSERVICE_API_KEY"
+	// APIKeyFileEnvVar is the environment variable holding the path of a file containing the API key
+	APIKeyFileEnvVar = "FRESHSERVICE_API_KEY_FILE"
+	// DomainEnvVar is the environment variable holding the Freshservice domain
+	DomainEnvVar = "FRESHSERVICE_DOMAIN"
+)
+
+// resolveAPIKey works out the API key of the provider. In order of precedence it
+// comes from the api_key argument, the api_key_file argument, FRESHSERVICE_API_KEY
+// and finally FRESHSERVICE_API_KEY_FILE. Arguments always win over the environment,
+// so a key in the configuration can't be overridden by a stale variable.
+func resolveAPIKey(d *schema.ResourceData) (string, error) {
+	if apiKey := d.Get("api_key").(string); apiKey != "" {
+		return apiKey, nil
+	}
+	if path := d.Get("api_key_file").(string); path != "" {
+		return readAPIKeyFile(path, "api_key_file")
+	}
+	if apiKey := os.Getenv(APIKeyEnvVar); apiKey != "" {
+		return apiKey, nil
+	}
+	if path := os.Getenv(APIKeyFileEnvVar); path != "" {
+		return readAPIKeyFile(path, APIKeyFileEnvVar)
+	}
+
+	return "", fmt.Errorf("no API key configured: set api_key or api_key_file, or the %s or %s environment variable", APIKeyEnvVar, APIKeyFileEnvVar)
+}
+
+// readAPIKeyFile reads an API key from a file, ignoring surrounding whitespace
+// such as the trailing newline of mounted secrets
+func readAPIKeyFile(path, source string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read API key file from %s: %w", source, err)
+	}
+
+	apiKey := strings.TrimSpace(string(data))
+	if apiKey == "" {
+		return "", fmt.Errorf("API key file %s from %s is empty", path, source)
+	}
+
+	return apiKey, nil
+}
@@ -2,26 +2,22 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lcp-llp/terraform-provider-freshservice/freshservice"
 )
 
-// AssetSearchResponse represents the API response for asset search
-type AssetSearchResponse struct {
-	Assets []Asset `json:"assets"`
-}
-
 func dataSourceAsset() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceAssetRead,
-		Description: "Data source to search for Freshservice assets by name, display_id, or asset_tag",
+		Description: "Data source to search for Freshservice assets by name, display_id, asset_tag, or a filter query",
 
 		Schema: map[string]*schema.Schema{
 			// Search parameters (at least one required)
@@ -40,6 +36,7 @@ func dataSourceAsset() *schema.Resource {
 				Optional:    true,
 				Description: "Asset tag to search for",
 			},
+			"filter": filterBlockSchema(),
 			"trashed": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -195,49 +192,76 @@ func dataSourceAssetRead(ctx context.Context, d *schema.ResourceData, meta inter
 	assetTag := d.Get("asset_tag").(string)
 	trashed := d.Get("trashed").(bool)
 
-	if name == "" && displayID == 0 && assetTag == "" {
-		return diag.Errorf("At least one of 'name', 'display_id', or 'asset_tag' must be provided")
-	}
-
-	// Build search query
-	searchQuery := buildSearchQuery(name, displayID, assetTag)
-
-	// URL encode the search query
-	encodedQuery := url.QueryEscape(searchQuery)
-
-	// Build the endpoint
-	endpoint := fmt.Sprintf("/assets?search=%s", encodedQuery)
-	if trashed {
-		endpoint += "&trashed=true"
-	}
-
-	// Make the API request
-	req, err := config.NewRequest(ctx, "GET", endpoint, nil)
+	filter, err := expandFilterBlock(d.Get("filter").([]interface{}), 0)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	resp, err := config.DoRequest(req)
-	if err != nil {
-		return diag.FromErr(err)
+	if name == "" && displayID == 0 && assetTag == "" && filter == nil {
+		return diag.Errorf("At least one of 'name', 'display_id', 'asset_tag', or 'filter' must be provided")
 	}
-	defer resp.Body.Close()
 
-	var searchResponse AssetSearchResponse
-	if err := json.NewDecoder(resp.Body).Decode(&searchResponse); err != nil {
-		return diag.Errorf("Failed to decode response: %s", err)
+	var assets []freshservice.Asset
+	// match checks the criteria the request can't express
+	match := func(asset freshservice.Asset) bool { return true }
+
+	switch {
+	case filter != nil:
+		// Use the filter endpoint when a filter block is given
+		filterQuery, err := buildAssetFilterQuery(name, assetTag, filter)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		assets, err = freshservice.Collect(config.Assets.List(ctx, &freshservice.AssetListOptions{Filter: filterQuery, Trashed: trashed}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		// The filter endpoint does not support display_id
+		match = func(asset freshservice.Asset) bool { return displayID == 0 || asset.DisplayID == displayID }
+	case displayID != 0:
+		// Neither does search, so the asset is read by its display ID
+		get := config.Assets.Get
+		if trashed {
+			get = config.Assets.GetTrashed
+		}
+		asset, err := get(ctx, displayID)
+		if err != nil && !errors.Is(err, freshservice.ErrNotFound) {
+			return diag.FromErr(err)
+		}
+		if asset != nil {
+			assets = append(assets, *asset)
+		}
+		match = func(asset freshservice.Asset) bool {
+			return (name == "" || asset.Name == name) && (assetTag == "" || asset.AssetTag == assetTag)
+		}
+	default:
+		// Search takes a single field, the asset tag being the more selective one
+		field, value := naturalKeyName, name
+		if assetTag != "" {
+			field, value = naturalKeyAssetTag, assetTag
+		}
+		// Search matches substrings, so "prod" also finds "prod-2"
+		match = func(asset freshservice.Asset) bool {
+			return (name == "" || asset.Name == name) && (assetTag == "" || asset.AssetTag == assetTag)
+		}
+		// Fetch every page of results so a match is never missed
+		assets, err = freshservice.Collect(config.Assets.List(ctx, &freshservice.AssetListOptions{Search: buildSearchQuery(field, value), Trashed: trashed}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	}
+	assets = slices.DeleteFunc(assets, func(asset freshservice.Asset) bool { return !match(asset) })
 
-	if len(searchResponse.Assets) == 0 {
+	if len(assets) == 0 {
 		return diag.Errorf("No assets found matching the search criteria")
 	}
 
-	if len(searchResponse.Assets) > 1 {
+	if len(assets) > 1 {
 		return diag.Errorf("Multiple assets found matching the search criteria. Please refine your search to return a single asset")
 	}
 
 	// Set the asset data
-	asset := searchResponse.Assets[0]
+	asset := assets[0]
 	d.SetId(strconv.Itoa(asset.DisplayID))
 
 	if err := d.Set("id", asset.ID); err != nil {
@@ -345,43 +369,36 @@ func dataSourceAssetRead(ctx context.Context, d *schema.ResourceData, meta inter
 	return nil
 }
 
-// buildSearchQuery builds the search query string based on provided parameters
-func buildSearchQuery(name string, displayID int, assetTag string) string {
-	var queryParts []string
+// buildSearchQuery builds a search query for a field. The search endpoint only supports
+// a single name, asset_tag, serial_number, mac_addresses or ip_addresses condition.
+func buildSearchQuery(field, value string) string {
+	return fmt.Sprintf(`"%s:'%s'"`, field, escapeSearchValue(value))
+}
 
-	if name != "" {
-		// Escape single quotes in the name
-		escapedName := escapeSearchValue(name)
-		queryParts = append(queryParts, fmt.Sprintf("name:'%s'", escapedName))
-	}
+// buildAssetFilterQuery combines name and asset_tag with a filter block into a filter query
+func buildAssetFilterQuery(name, assetTag string, filter *filterGroup) (string, error) {
+	group := filterGroup{Match: "all", Groups: []filterGroup{*filter}}
 
-	if displayID != 0 {
-		queryParts = append(queryParts, fmt.Sprintf("display_id:%d", displayID))
+	if name != "" {
+		group.Conditions = append(group.Conditions, filterCondition{Field: "name", Values: []string{name}})
 	}
-
 	if assetTag != "" {
-		escapedAssetTag := escapeSearchValue(assetTag)
-		queryParts = append(queryParts, fmt.Sprintf("asset_tag:'%s'", escapedAssetTag))
+		group.Conditions = append(group.Conditions, filterCondition{Field: "asset_tag", Values: []string{assetTag}})
 	}
 
-	// Join with AND operator if multiple criteria
-	if len(queryParts) > 1 {
-		query := ""
-		for i, part := range queryParts {
-			if i > 0 {
-				query += " AND "
-			}
-			query += part
-		}
-		return fmt.Sprintf("\"%s\"", query)
+	query, err := group.render()
+	if err != nil {
+		return "", err
+	}
+	if query == "" {
+		return "", fmt.Errorf("filter must contain at least one condition")
 	}
 
-	// Single criterion
-	return fmt.Sprintf("\"%s\"", queryParts[0])
+	return wrapFilterQuery(query), nil
 }
 
-// escapeSearchValue escapes single quotes in search values
+// escapeSearchValue escapes characters that would end a quoted search value.
+// Backslashes are escaped first so the escapes added for quotes stay intact.
 func escapeSearchValue(value string) string {
-	// Replace single quotes with escaped single quotes for Freshservice API
-	return strings.ReplaceAll(value, "'", "\\'")
+	return strings.NewReplacer(`\`, `\\`, "'", `\'`, `"`, `\"`).Replace(value)
 }
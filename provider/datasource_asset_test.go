@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDataSourceAsset_search(t *testing.T) {
+	h := newTestHarness(t)
+
+	laptop := h.apply("freshservice_asset", nil, map[string]interface{}{
+		"name":          "Bob's laptop",
+		"asset_type_id": testHardwareTypeID,
+		"type_fields":   map[string]interface{}{"environment": "Production"},
+	})
+	h.apply("freshservice_asset", nil, map[string]interface{}{
+		"name":          "Alice's laptop",
+		"asset_type_id": testHardwareTypeID,
+		"type_fields":   map[string]interface{}{"environment": "Test"},
+	})
+
+	// By name, with a quote that must be escaped in the query
+	state, err := h.readData("freshservice_asset", map[string]interface{}{"name": "Bob's laptop"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireAttributes(t, state, map[string]string{
+		"display_id":    laptop.ID,
+		"asset_type_id": "25",
+		"asset_tag":     "ASSET-" + laptop.ID,
+	})
+
+	// By display ID and asset tag
+	displayID, _ := strconv.Atoi(laptop.ID)
+	state, err = h.readData("freshservice_asset", map[string]interface{}{"display_id": displayID, "asset_tag": "ASSET-" + laptop.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireAttributes(t, state, map[string]string{"name": "Bob's laptop"})
+
+	// Search takes the asset tag, and the name must then match as well
+	if _, err := h.readData("freshservice_asset", map[string]interface{}{"name": "Alice's laptop", "asset_tag": "ASSET-" + laptop.ID}); err == nil || !strings.Contains(err.Error(), "No assets found") {
+		t.Fatalf("expected no match, got %v", err)
+	}
+
+	// By filter on a type field
+	state, err = h.readData("freshservice_asset", map[string]interface{}{
+		"filter": []interface{}{map[string]interface{}{
+			"asset_type_id": testHardwareTypeID,
+			"condition": []interface{}{map[string]interface{}{
+				"type_field": "environment",
+				"values":     []interface{}{"Test"},
+			}},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireAttributes(t, state, map[string]string{"name": "Alice's laptop"})
+
+	// Trashed assets are only found when asked for
+	h.destroy("freshservice_asset", laptop)
+	if _, err := h.readData("freshservice_asset", map[string]interface{}{"name": "Bob's laptop"}); err == nil || !strings.Contains(err.Error(), "No assets found") {
+		t.Fatalf("expected no match, got %v", err)
+	}
+	state, err = h.readData("freshservice_asset", map[string]interface{}{"name": "Bob's laptop", "trashed": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireAttributes(t, state, map[string]string{"display_id": laptop.ID})
+	if _, err := h.readData("freshservice_asset", map[string]interface{}{"display_id": displayID}); err == nil || !strings.Contains(err.Error(), "No assets found") {
+		t.Fatalf("expected no match, got %v", err)
+	}
+	state, err = h.readData("freshservice_asset", map[string]interface{}{"display_id": displayID, "trashed": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireAttributes(t, state, map[string]string{"name": "Bob's laptop"})
+}
+
+func TestDataSourceAsset_ambiguous(t *testing.T) {
+	h := newTestHarness(t)
+
+	for i := 0; i < 2; i++ {
+		h.apply("freshservice_asset", nil, map[string]interface{}{"name": "Spare", "asset_type_id": testHardwareTypeID})
+	}
+
+	_, err := h.readData("freshservice_asset", map[string]interface{}{"name": "Spare"})
+	if err == nil || !strings.Contains(err.Error(), "Multiple assets found") {
+		t.Fatalf("expected an ambiguous match error, got %v", err)
+	}
+}
+
+func TestDataSourceAsset_exactName(t *testing.T) {
+	h := newTestHarness(t)
+
+	prod := h.apply("freshservice_asset", nil, map[string]interface{}{"name": "prod", "asset_type_id": testHardwareTypeID})
+	h.apply("freshservice_asset", nil, map[string]interface{}{"name": "prod-2", "asset_type_id": testHardwareTypeID})
+
+	// Search matches both, but only the exact name is returned
+	state, err := h.readData("freshservice_asset", map[string]interface{}{"name": "prod"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireAttributes(t, state, map[string]string{"display_id": prod.ID})
+
+	if _, err := h.readData("freshservice_asset", map[string]interface{}{"name": "pro"}); err == nil || !strings.Contains(err.Error(), "No assets found") {
+		t.Fatalf("expected no match for a partial name, got %v", err)
+	}
+}
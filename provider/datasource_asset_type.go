@@ -2,20 +2,14 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lcp-llp/terraform-provider-freshservice/freshservice"
 )
 
-// AssetTypesListResponse represents the API response for listing asset types
-type AssetTypesListResponse struct {
-	AssetTypes []AssetType `json:"asset_types"`
-}
-
 func dataSourceAssetType() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceAssetTypeRead,
@@ -82,53 +76,29 @@ func dataSourceAssetTypeRead(ctx context.Context, d *schema.ResourceData, meta i
 
 // readAssetTypeByID retrieves an asset type by its ID
 func readAssetTypeByID(ctx context.Context, d *schema.ResourceData, config *Config, id int) diag.Diagnostics {
-	endpoint := fmt.Sprintf("/asset_types/%d", id)
-	req, err := config.NewRequest(ctx, "GET", endpoint, nil)
+	assetType, err := config.AssetTypes.Get(ctx, id)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	resp, err := config.DoRequest(req)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	defer resp.Body.Close()
-
-	var assetTypeResp AssetTypeResponse
-	if err := json.NewDecoder(resp.Body).Decode(&assetTypeResp); err != nil {
-		return diag.Errorf("Failed to decode response: %s", err)
-	}
-
 	// Set the ID and data
-	d.SetId(strconv.Itoa(assetTypeResp.AssetType.ID))
-	return setAssetTypeDataSourceData(d, &assetTypeResp.AssetType)
+	d.SetId(strconv.Itoa(assetType.ID))
+	return setAssetTypeDataSourceData(d, assetType)
 }
 
 // readAssetTypeByName retrieves an asset type by searching by name
 func readAssetTypeByName(ctx context.Context, d *schema.ResourceData, config *Config, name string) diag.Diagnostics {
-	// List all asset types and find the one with matching name
-	endpoint := "/asset_types"
-	req, err := config.NewRequest(ctx, "GET", endpoint, nil)
+	// List all asset types across every page and find the one with matching name
+	assetTypes, err := freshservice.Collect(config.AssetTypes.List(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	resp, err := config.DoRequest(req)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	defer resp.Body.Close()
-
-	var assetTypesResp AssetTypesListResponse
-	if err := json.NewDecoder(resp.Body).Decode(&assetTypesResp); err != nil {
-		return diag.Errorf("Failed to decode response: %s", err)
-	}
-
 	// Find asset type with matching name
-	var foundAssetType *AssetType
-	for _, assetType := range assetTypesResp.AssetTypes {
-		if assetType.Name == name {
-			foundAssetType = &assetType
+	var foundAssetType *freshservice.AssetType
+	for i := range assetTypes {
+		if assetTypes[i].Name == name {
+			foundAssetType = &assetTypes[i]
 			break
 		}
 	}
@@ -143,7 +113,7 @@ func readAssetTypeByName(ctx context.Context, d *schema.ResourceData, config *Co
 }
 
 // setAssetTypeDataSourceData sets the asset type data for the data source
-func setAssetTypeDataSourceData(d *schema.ResourceData, assetType *AssetType) diag.Diagnostics {
+func setAssetTypeDataSourceData(d *schema.ResourceData, assetType *freshservice.AssetType) diag.Diagnostics {
 	if err := d.Set("id", assetType.ID); err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDataSourceAssetType_read(t *testing.T) {
+	h := newTestHarness(t)
+	h.config.PageSize = 2
+
+	// By name, across pages
+	state, err := h.readData("freshservice_asset_type", map[string]interface{}{"name": "Laptop"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireAttributes(t, state, map[string]string{
+		"id":                   "26",
+		"parent_asset_type_id": "25",
+	})
+
+	// By ID
+	state, err = h.readData("freshservice_asset_type", map[string]interface{}{"id": 56000947175})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireAttributes(t, state, map[string]string{"name": "AWS Account"})
+
+	if _, err := h.readData("freshservice_asset_type", map[string]interface{}{"name": "Spaceship"}); err == nil || !strings.Contains(err.Error(), "Spaceship") {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
@@ -0,0 +1,308 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lcp-llp/terraform-provider-freshservice/freshservice"
+)
+
+func dataSourceAssets() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAssetsRead,
+		Description: "Data source to list Freshservice assets matching a set of filters",
+
+		Schema: map[string]*schema.Schema{
+			// Filter parameters (all optional, combined with AND)
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the assets to return",
+			},
+			"asset_tag": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Asset tag of the assets to return",
+			},
+			"display_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Display ID of the asset to return",
+			},
+			"asset_type_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Only return assets of this asset type",
+			},
+			"location_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Only return assets in this location",
+			},
+			"department_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Only return assets belonging to this department",
+			},
+			"agent_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Only return assets managed by this agent",
+			},
+			"usage_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return assets with this usage type (permanent, loaner)",
+			},
+			"impact": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return assets with this impact level (low, medium, high)",
+			},
+			"query": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Raw Freshservice filter query, e.g. \"asset_state:'In Use' AND created_at:>'2024-01-01'\"",
+			},
+			"filter": filterBlockSchema(),
+			"trashed": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "List assets in trash instead of active assets (default: false)",
+			},
+
+			// Output fields
+			"assets": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Assets matching the filters",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID of the asset",
+						},
+						"display_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Display ID of the asset",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the asset",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Description of the asset",
+						},
+						"asset_type_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Asset type ID",
+						},
+						"asset_tag": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Asset tag",
+						},
+						"impact": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Impact level of the asset",
+						},
+						"usage_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Usage type of the asset",
+						},
+						"user_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "User ID assigned to the asset",
+						},
+						"location_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Location ID of the asset",
+						},
+						"department_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Department ID of the asset",
+						},
+						"agent_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Agent ID assigned to the asset",
+						},
+						"group_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Group ID assigned to the asset",
+						},
+						"workspace_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Workspace ID of the asset",
+						},
+						"created_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Creation timestamp of the asset",
+						},
+						"updated_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Last update timestamp of the asset",
+						},
+						"type_fields": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Description: "Custom type fields of the asset, without the asset type ID suffix",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAssetsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	// Build the filter query from the server-side filterable fields
+	filterQuery, err := buildAssetsFilterQuery(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	opts := &freshservice.AssetListOptions{
+		Filter:  filterQuery,
+		Trashed: d.Get("trashed").(bool),
+	}
+
+	// Fetch every page of results
+	assets, err := freshservice.Collect(config.Assets.List(ctx, opts))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Apply the criteria the filter endpoint does not support
+	displayID := d.Get("display_id").(int)
+	usageType := d.Get("usage_type").(string)
+	impact := d.Get("impact").(string)
+
+	results := make([]interface{}, 0, len(assets))
+	for _, asset := range assets {
+		if displayID != 0 && asset.DisplayID != displayID {
+			continue
+		}
+		if usageType != "" && !strings.EqualFold(asset.UsageType, usageType) {
+			continue
+		}
+		if impact != "" && !strings.EqualFold(asset.Impact, impact) {
+			continue
+		}
+		results = append(results, flattenAsset(&asset))
+	}
+
+	if err := d.Set("assets", results); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(fmt.Sprintf("%s|%t|%d|%s|%s", opts.Filter, opts.Trashed, displayID, usageType, impact))))
+
+	return nil
+}
+
+// buildAssetsFilterQuery builds the filter query string for the assets data source
+func buildAssetsFilterQuery(d *schema.ResourceData) (string, error) {
+	group := filterGroup{Match: "all"}
+
+	if name := d.Get("name").(string); name != "" {
+		group.Conditions = append(group.Conditions, filterCondition{Field: "name", Values: []string{name}})
+	}
+	if assetTag := d.Get("asset_tag").(string); assetTag != "" {
+		group.Conditions = append(group.Conditions, filterCondition{Field: "asset_tag", Values: []string{assetTag}})
+	}
+	for _, field := range []string{"asset_type_id", "location_id", "department_id", "agent_id"} {
+		if value := d.Get(field).(int); value != 0 {
+			group.Conditions = append(group.Conditions, filterCondition{Field: field, Values: []string{strconv.Itoa(value)}})
+		}
+	}
+
+	filter, err := expandFilterBlock(d.Get("filter").([]interface{}), d.Get("asset_type_id").(int))
+	if err != nil {
+		return "", err
+	}
+	if filter != nil {
+		group.Groups = append(group.Groups, *filter)
+	}
+
+	query, err := group.render()
+	if err != nil {
+		return "", err
+	}
+
+	if raw := strings.Trim(strings.TrimSpace(d.Get("query").(string)), "\""); raw != "" {
+		if query != "" {
+			query += " AND "
+		}
+		query += fmt.Sprintf("(%s)", raw)
+	}
+
+	if query == "" {
+		return "", nil
+	}
+
+	return wrapFilterQuery(query), nil
+}
+
+// flattenAsset converts an asset into a map for list attributes
+func flattenAsset(asset *freshservice.Asset) map[string]interface{} {
+	result := map[string]interface{}{
+		"id":            asset.ID,
+		"display_id":    asset.DisplayID,
+		"name":          asset.Name,
+		"description":   asset.Description,
+		"asset_type_id": asset.AssetTypeID,
+		"asset_tag":     asset.AssetTag,
+		"impact":        asset.Impact,
+		"usage_type":    asset.UsageType,
+		"workspace_id":  asset.WorkspaceID,
+		"created_at":    asset.CreatedAt.Format(time.RFC3339),
+		"updated_at":    asset.UpdatedAt.Format(time.RFC3339),
+		"type_fields":   flattenTypeFields(asset.TypeFields, asset.AssetTypeID),
+	}
+
+	// Handle nullable fields
+	if asset.UserID != nil {
+		result["user_id"] = *asset.UserID
+	}
+	if asset.LocationID != nil {
+		result["location_id"] = *asset.LocationID
+	}
+	if asset.DepartmentID != nil {
+		result["department_id"] = *asset.DepartmentID
+	}
+	if asset.AgentID != nil {
+		result["agent_id"] = *asset.AgentID
+	}
+	if asset.GroupID != nil {
+		result["group_id"] = *asset.GroupID
+	}
+
+	return result
+}
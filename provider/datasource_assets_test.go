@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDataSourceAssets_filters(t *testing.T) {
+	h := newTestHarness(t)
+	h.config.PageSize = 2
+
+	for i := 1; i <= 5; i++ {
+		impact := "low"
+		if i%2 == 0 {
+			impact = "high"
+		}
+		h.apply("freshservice_asset", nil, map[string]interface{}{
+			"name":          fmt.Sprintf("Server %d", i),
+			"asset_type_id": testHardwareTypeID,
+			"impact":        impact,
+			"type_fields":   map[string]interface{}{"quantity": fmt.Sprint(i)},
+		})
+	}
+	h.apply("freshservice_aws_account", nil, map[string]interface{}{
+		"account_name": "Production",
+		"account_id":   "123456789012",
+	})
+
+	// Every page is fetched
+	state, err := h.readData("freshservice_assets", map[string]interface{}{"asset_type_id": testHardwareTypeID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireAttributes(t, state, map[string]string{
+		"assets.#":                      "5",
+		"assets.0.name":                 "Server 1",
+		"assets.4.type_fields.quantity": "5",
+	})
+	if n := h.fake.CountRequests("GET /api/v2/assets?"); n != 3 {
+		t.Errorf("expected 3 page requests, got %d", n)
+	}
+
+	// Client side filters and raw queries
+	state, err = h.readData("freshservice_assets", map[string]interface{}{
+		"asset_type_id": testHardwareTypeID,
+		"impact":        "high",
+		"query":         "quantity_25:>3",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireAttributes(t, state, map[string]string{
+		"assets.#":      "1",
+		"assets.0.name": "Server 4",
+	})
+
+	// No matches is an empty list, not an error
+	state, err = h.readData("freshservice_assets", map[string]interface{}{"name": "Server 42"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireAttributes(t, state, map[string]string{"assets.#": "0"})
+
+	// Fields the filter endpoint doesn't support are rejected by the API
+	_, err = h.readData("freshservice_assets", map[string]interface{}{"query": "display_id:1"})
+	if err == nil || !strings.Contains(err.Error(), "display_id is not a supported filter field") {
+		t.Errorf("expected an unsupported filter field error, got %v", err)
+	}
+}
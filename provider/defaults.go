@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lcp-llp/terraform-provider-freshservice/freshservice"
+)
+
+// managedBySeparator separates the description of an asset from the managed_by marker
+const managedBySeparator = "\n\n"
+
+// assetDefaults holds the defaults block of the provider, applied to every asset
+type assetDefaults struct {
+	// TypeFields are set on every asset whose asset type has a field of that name
+	// (without the asset type ID suffix), unless the resource sets the field itself
+	TypeFields map[string]string
+	// ManagedBy is appended to the description of every asset
+	ManagedBy string
+}
+
+// expandAssetDefaults reads the defaults block of the provider configuration
+func expandAssetDefaults(raw []interface{}) assetDefaults {
+	defaults := assetDefaults{TypeFields: make(map[string]string)}
+	if len(raw) == 0 || raw[0] == nil {
+		return defaults
+	}
+
+	block := raw[0].(map[string]interface{})
+	for key, value := range block["type_fields"].(map[string]interface{}) {
+		defaults.TypeFields[key] = value.(string)
+	}
+	defaults.ManagedBy = block["managed_by"].(string)
+
+	return defaults
+}
+
+// typeFieldsAllSchema returns the schema of type_fields_all, the type fields of an
+// asset including the ones set by the provider defaults
+func typeFieldsAllSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Computed:    true,
+		Description: "Type fields managed for the asset, including the type_fields set by the defaults block of the provider",
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+// mergeTypeFields returns the configured type_fields with the default type fields for
+// fields of the asset type they don't set. Default fields the asset type doesn't have
+// are skipped, so one set of defaults can serve assets of every type.
+func (d assetDefaults) mergeTypeFields(fields *assetTypeFieldSet, configured map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(configured)+len(d.TypeFields))
+	set := make(map[string]bool)
+	for key, value := range configured {
+		merged[key] = value
+		if field, ok := fields.Lookup(key); ok {
+			set[field.Name] = true
+		}
+	}
+
+	for key, value := range d.TypeFields {
+		if _, ok := merged[key]; ok {
+			continue
+		}
+		field, ok := fields.Lookup(key)
+		if !ok || set[field.Name] {
+			continue
+		}
+		merged[key] = value
+		set[field.Name] = true
+	}
+
+	return merged
+}
+
+// applyTypeFields adds the default type fields to the type fields sent for a resource
+// with dedicated attributes. Only the fields of attrs, mapping field names without the
+// asset type ID suffix to attributes, that the resource leaves empty are added.
+func (d assetDefaults) applyTypeFields(typeFields map[string]interface{}, assetTypeID int, attrs map[string]string) {
+	for field := range attrs {
+		name := fmt.Sprintf("%s_%d", field, assetTypeID)
+		if _, ok := typeFields[name]; ok {
+			continue
+		}
+		if value := d.TypeFields[field]; value != "" {
+			typeFields[name] = value
+		}
+	}
+}
+
+// attributeTypeFieldsAll returns type_fields_all of a resource with dedicated attributes:
+// the value of every attribute of attrs, or its default type field when it is empty
+func (d assetDefaults) attributeTypeFieldsAll(get func(attr string) string, attrs map[string]string) map[string]string {
+	all := make(map[string]string)
+	for field, attr := range attrs {
+		value := get(attr)
+		if value == "" {
+			value = d.TypeFields[field]
+		}
+		if value != "" {
+			all[field] = value
+		}
+	}
+	return all
+}
+
+// stampDescription appends the managed_by marker to a description
+func (d assetDefaults) stampDescription(description string) string {
+	switch {
+	case d.ManagedBy == "":
+		return description
+	case description == "":
+		return d.ManagedBy
+	default:
+		return description + managedBySeparator + d.ManagedBy
+	}
+}
+
+// unstampDescription removes the managed_by marker from a description read from the API,
+// so the description in state matches the configuration
+func (d assetDefaults) unstampDescription(description string) string {
+	if d.ManagedBy == "" {
+		return description
+	}
+	if description == d.ManagedBy {
+		return ""
+	}
+	return strings.TrimSuffix(description, managedBySeparator+d.ManagedBy)
+}
+
+// attributeTypeFieldsAllCustomizeDiff plans type_fields_all of a resource with dedicated
+// attributes for the type fields of attrs
+func attributeTypeFieldsAllCustomizeDiff(attrs map[string]string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		for _, attr := range attrs {
+			if !d.NewValueKnown(attr) {
+				return d.SetNewComputed("type_fields_all")
+			}
+		}
+
+		config := meta.(*Config)
+		return d.SetNew("type_fields_all", config.Defaults.attributeTypeFieldsAll(func(attr string) string {
+			return d.Get(attr).(string)
+		}, attrs))
+	}
+}
+
+// setTypeFieldAttribute sets an attribute read from a type field of the asset. An empty
+// attribute stays empty when the asset holds the default of the field, so defaults
+// don't show up as diffs.
+func setTypeFieldAttribute(d *schema.ResourceData, config *Config, attr, field, value string) error {
+	if d.Get(attr).(string) == "" && value != "" && config.Defaults.TypeFields[field] == value {
+		return nil
+	}
+	return d.Set(attr, value)
+}
+
+// attributeTypeFields builds the type fields of a request from the dedicated attributes
+// of a resource. Values are sent as strings, so IDs keep their leading zeros.
+func attributeTypeFields(d *schema.ResourceData, assetTypeID int, attrs map[string]string) map[string]interface{} {
+	typeFields := make(map[string]interface{})
+	for field, attr := range attrs {
+		if value := d.Get(attr).(string); value != "" {
+			typeFields[fmt.Sprintf("%s_%d", field, assetTypeID)] = value
+		}
+	}
+	return typeFields
+}
+
+// setTypeFieldAttributes sets the dedicated attributes of a resource from the type fields
+// of the asset. Numbers and booleans are formatted the way they are configured.
+func setTypeFieldAttributes(d *schema.ResourceData, config *Config, asset *freshservice.Asset, attrs map[string]string) error {
+	for field, attr := range attrs {
+		value := asset.TypeFields[fmt.Sprintf("%s_%d", field, asset.AssetTypeID)]
+		if value == nil {
+			continue
+		}
+		if err := setTypeFieldAttribute(d, config, attr, field, freshservice.FormatFieldValue(value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setAttributeTypeFieldsAll sets type_fields_all of a resource with dedicated attributes
+// from the type fields of the asset. It holds the fields of attrs that are set, either by
+// their attribute or by the defaults.
+func setAttributeTypeFieldsAll(d *schema.ResourceData, config *Config, asset *freshservice.Asset, attrs map[string]string) error {
+	all := make(map[string]string)
+	for field, attr := range attrs {
+		if d.Get(attr).(string) == "" && config.Defaults.TypeFields[field] == "" {
+			continue
+		}
+		if value := asset.TypeFields[fmt.Sprintf("%s_%d", field, asset.AssetTypeID)]; value != nil {
+			all[field] = freshservice.FormatFieldValue(value)
+		}
+	}
+	return d.Set("type_fields_all", all)
+}
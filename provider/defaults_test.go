@@ -0,0 +1,189 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testDefaults are provider defaults covering fields of the AWS account and hardware asset types
+var testDefaults = assetDefaults{
+	TypeFields: map[string]string{
+		"owner":       "platform@example.com",
+		"po":          "PO-DEFAULT",
+		"approved_by": "cab@example.com",
+		"po_number":   "PO-HW",
+		"serial":      "SN-DEFAULT",
+	},
+	ManagedBy: "Managed by Terraform",
+}
+
+func TestConfigureProvider_defaults(t *testing.T) {
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"api_key": testAPIKey,
+		"domain":  "acme",
+		"defaults": []interface{}{map[string]interface{}{
+			"type_fields": map[string]interface{}{"owner": "platform@example.com"},
+			"managed_by":  "Managed by Terraform",
+		}},
+	}))
+	requireNoDiags(t, diags)
+
+	defaults := p.Meta().(*Config).Defaults
+	if defaults.TypeFields["owner"] != "platform@example.com" || defaults.ManagedBy != "Managed by Terraform" {
+		t.Errorf("unexpected defaults %#v", defaults)
+	}
+}
+
+func TestDefaults_awsAccount(t *testing.T) {
+	h := newTestHarness(t)
+	h.config.Defaults = testDefaults
+
+	config := map[string]interface{}{
+		"account_name": "Production",
+		"account_id":   "123456789012",
+		"owner":        "aws.admin@example.com",
+		"description":  "Main account",
+	}
+
+	// The plan shows the type fields set by the defaults
+	diff, err := h.plan("freshservice_aws_account", nil, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, want := range map[string]string{
+		"type_fields_all.owner":       "aws.admin@example.com",
+		"type_fields_all.po":          "PO-DEFAULT",
+		"type_fields_all.approved_by": "cab@example.com",
+	} {
+		if attr, ok := diff.Attributes[key]; !ok || attr.New != want {
+			t.Errorf("expected %s to be planned as %q, got %#v", key, want, attr)
+		}
+	}
+
+	state := h.apply("freshservice_aws_account", nil, config)
+	requireAttributes(t, state, map[string]string{
+		"owner":                       "aws.admin@example.com",
+		"description":                 "Main account",
+		"type_fields_all.%":           "4",
+		"type_fields_all.po":          "PO-DEFAULT",
+		"type_fields_all.approved_by": "cab@example.com",
+	})
+
+	if po := state.Attributes["po_number"]; po != "" {
+		t.Errorf("expected po_number to stay empty, got %q", po)
+	}
+
+	displayID, _ := strconv.Atoi(state.ID)
+	asset := h.fake.Asset(displayID)
+	typeFields := asset["type_fields"].(map[string]interface{})
+	if typeFields["owner_56000947175"] != "aws.admin@example.com" || typeFields["po_56000947175"] != "PO-DEFAULT" {
+		t.Errorf("unexpected type fields sent: %#v", typeFields)
+	}
+	if asset["description"] != "Main account\n\nManaged by Terraform" {
+		t.Errorf("expected the description to be stamped, got %q", asset["description"])
+	}
+
+	state = h.refresh("freshservice_aws_account", state)
+	h.requireNoPlan("freshservice_aws_account", state, config)
+
+	// Drift on a default field is put back
+	h.fake.UpdateAsset(displayID, nil, map[string]interface{}{"po_56000947175": "PO-OTHER"})
+	state = h.refresh("freshservice_aws_account", state)
+	state = h.apply("freshservice_aws_account", state, config)
+	if po := h.fake.Asset(displayID)["type_fields"].(map[string]interface{})["po_56000947175"]; po != "PO-DEFAULT" {
+		t.Errorf("expected the default PO to be restored, got %#v", po)
+	}
+	h.requireNoPlan("freshservice_aws_account", h.refresh("freshservice_aws_account", state), config)
+}
+
+func TestDefaults_cloudAsset(t *testing.T) {
+	h := newTestHarness(t)
+	h.config.Defaults = testDefaults
+
+	// The default serial satisfies the mandatory field, and the owner default is
+	// skipped because the asset type has no such field
+	config := map[string]interface{}{
+		"name":          "Build Server",
+		"asset_type_id": testLaptopTypeID,
+		"type_fields": map[string]interface{}{
+			"product": "ThinkPad",
+		},
+	}
+
+	state := h.apply("freshservice_cloud_asset", nil, config)
+	requireAttributes(t, state, map[string]string{
+		"description":               "",
+		"type_fields.%":             "1",
+		"type_fields_all.%":         "3",
+		"type_fields_all.product":   "ThinkPad",
+		"type_fields_all.po_number": "PO-HW",
+		"type_fields_all.serial":    "SN-DEFAULT",
+	})
+
+	displayID, _ := strconv.Atoi(state.ID)
+	asset := h.fake.Asset(displayID)
+	typeFields := asset["type_fields"].(map[string]interface{})
+	if typeFields["serial_26"] != "SN-DEFAULT" || typeFields["po_number_25"] != "PO-HW" {
+		t.Errorf("unexpected type fields sent: %#v", typeFields)
+	}
+	if asset["description"] != "Managed by Terraform" {
+		t.Errorf("expected the description to be the marker, got %q", asset["description"])
+	}
+
+	h.requireNoPlan("freshservice_cloud_asset", h.refresh("freshservice_cloud_asset", state), config)
+
+	// Values set by the resource take precedence
+	config["type_fields"].(map[string]interface{})["serial"] = "SN-0001"
+	state = h.apply("freshservice_cloud_asset", state, config)
+	requireAttributes(t, state, map[string]string{"type_fields_all.serial": "SN-0001"})
+	h.requireNoPlan("freshservice_cloud_asset", h.refresh("freshservice_cloud_asset", state), config)
+}
+
+func TestDefaults_asset(t *testing.T) {
+	h := newTestHarness(t)
+	h.config.Defaults = testDefaults
+
+	config := map[string]interface{}{
+		"name":          "Switch",
+		"asset_type_id": testHardwareTypeID,
+		"description":   "Core switch",
+	}
+
+	state := h.apply("freshservice_asset", nil, config)
+	requireAttributes(t, state, map[string]string{
+		"description":               "Core switch",
+		"type_fields.%":             "0",
+		"type_fields_all.%":         "1",
+		"type_fields_all.po_number": "PO-HW",
+	})
+	h.requireNoPlan("freshservice_asset", h.refresh("freshservice_asset", state), config)
+
+	// Changing the marker rewrites the description
+	h.config.Defaults.ManagedBy = "Managed by Terraform (network)"
+	state = h.refresh("freshservice_asset", state)
+	state = h.apply("freshservice_asset", state, config)
+
+	displayID, _ := strconv.Atoi(state.ID)
+	if description := h.fake.Asset(displayID)["description"]; description != "Core switch\n\nManaged by Terraform (network)" {
+		t.Errorf("expected the new marker, got %q", description)
+	}
+}
+
+func TestAssetDefaults_description(t *testing.T) {
+	defaults := assetDefaults{ManagedBy: "Managed by Terraform"}
+
+	for _, description := range []string{"", "Main account", "Line 1\n\nLine 2"} {
+		stamped := defaults.stampDescription(description)
+		if got := defaults.unstampDescription(stamped); got != description {
+			t.Errorf("expected %q back from %q, got %q", description, stamped, got)
+		}
+	}
+
+	if got := (assetDefaults{}).stampDescription("Main account"); got != "Main account" {
+		t.Errorf("expected no marker without managed_by, got %q", got)
+	}
+}
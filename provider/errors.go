@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/lcp-llp/terraform-provider-freshservice/freshservice"
+)
+
+// apiFieldResolver maps a field name reported by the API to a resource attribute path.
+// It returns nil when the field does not correspond to any attribute.
+type apiFieldResolver func(field string) cty.Path
+
+// apiErrorDiagnostics converts an error into diagnostics. Field errors from the API
+// become one diagnostic each, pointing at the attribute resolved for the field.
+func apiErrorDiagnostics(err error, resolve apiFieldResolver) diag.Diagnostics {
+	var apiErr *freshservice.APIError
+	if !errors.As(err, &apiErr) || len(apiErr.Errors) == 0 {
+		return diag.FromErr(err)
+	}
+
+	summary := apiErr.Description
+	if summary == "" {
+		summary = fmt.Sprintf("API request failed with status %d", apiErr.StatusCode)
+	}
+
+	var diags diag.Diagnostics
+	for _, fieldErr := range apiErr.Errors {
+		d := diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   fieldErr.String(),
+		}
+		if resolve != nil && fieldErr.Field != "" {
+			d.AttributePath = resolve(fieldErr.Field)
+		}
+		diags = append(diags, d)
+	}
+
+	return diags
+}
+
+// attributeFieldResolver builds a resolver from a plain field to attribute mapping
+func attributeFieldResolver(attributes map[string]string) apiFieldResolver {
+	return func(field string) cty.Path {
+		if attr, ok := attributes[field]; ok {
+			return cty.GetAttrPath(attr)
+		}
+		return nil
+	}
+}
+
+// assetFieldResolver builds a resolver for asset-backed resources.
+// attributes maps top level API fields to attribute names. typeFields maps type
+// field names (without the asset type ID suffix) to attribute names.
+func assetFieldResolver(assetTypeID int, attributes map[string]string, typeFields map[string]string) apiFieldResolver {
+	suffix := fmt.Sprintf("_%d", assetTypeID)
+
+	return func(field string) cty.Path {
+		if attr, ok := attributes[field]; ok {
+			return cty.GetAttrPath(attr)
+		}
+
+		isTypeField := strings.HasPrefix(field, "type_fields.")
+		name := strings.TrimPrefix(field, "type_fields.")
+		if strings.HasSuffix(name, suffix) {
+			isTypeField = true
+			name = strings.TrimSuffix(name, suffix)
+		}
+		if !isTypeField {
+			return nil
+		}
+
+		if attr, ok := typeFields[name]; ok {
+			return cty.GetAttrPath(attr)
+		}
+		return nil
+	}
+}
+
+// configuredTypeFieldResolver maps fields reported in API errors to the attributes of an
+// asset resource. Type fields map to the type_fields key they were configured with, keys
+// holding the configured key of each full field name.
+func configuredTypeFieldResolver(attributes map[string]string, keys map[string]string) apiFieldResolver {
+	return func(field string) cty.Path {
+		if attr, ok := attributes[field]; ok {
+			return cty.GetAttrPath(attr)
+		}
+		if key, ok := keys[strings.TrimPrefix(field, "type_fields.")]; ok {
+			return cty.GetAttrPath("type_fields").IndexString(key)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,1549 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lcp-llp/terraform-provider-freshservice/freshservice"
+)
+
+// testAPIKey is the API key the fake Freshservice API accepts
+const testAPIKey = "test-api-key"
+
+// Asset type IDs seeded into the fake Freshservice API
+const (
+	testHardwareTypeID = 25
+	testLaptopTypeID   = 26
+)
+
+// Workspace IDs of the fake Freshservice API. New assets land in the IT workspace
+// unless another one is given.
+const (
+	testITWorkspaceID       = 2
+	testCloudOpsWorkspaceID = 3
+)
+
+// fakeWorkspaces are the workspaces assets can be created in or moved to
+var fakeWorkspaces = map[int]string{
+	testITWorkspaceID:       "IT",
+	testCloudOpsWorkspaceID: "Cloud Ops",
+}
+
+// fakeFreshservice is an in-memory stand-in for the Freshservice API v2. It implements
+// the asset, asset type and asset type field endpoints used by the provider, including
+// search and filter queries, pagination, trash, error payloads and rate limiting.
+type fakeFreshservice struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mu          sync.Mutex
+	assets      map[int]map[string]interface{}
+	assetTypes  map[int]map[string]interface{}
+	fields      map[int][]map[string]interface{}
+	nextID      int
+	nextDisplay int
+	nextFieldID int
+	clock       time.Time
+
+	// requests records every request as "METHOD /path?query"
+	requests []string
+	// throttled is the number of upcoming requests answered with 429
+	throttled int
+	// failures is the number of upcoming requests answered with failStatus
+	failures   int
+	failStatus int
+	// omitFieldIDs leaves the new field out of the response when creating a field
+	omitFieldIDs bool
+}
+
+// newFakeFreshservice starts a fake Freshservice API seeded with the AWS, Azure and GCP
+// asset types used by the dedicated resources and a hardware type with one field of
+// every data type. The server is closed when the test ends.
+func newFakeFreshservice(t *testing.T) *fakeFreshservice {
+	t.Helper()
+
+	f := &fakeFreshservice{
+		t:           t,
+		assets:      make(map[int]map[string]interface{}),
+		assetTypes:  make(map[int]map[string]interface{}),
+		fields:      make(map[int][]map[string]interface{}),
+		nextID:      56000100000,
+		nextDisplay: 1,
+		nextFieldID: 56000200000,
+		clock:       time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+	}
+
+	f.addAssetType(defaultAWSAccountTypeID, "AWS Account", 0)
+	f.addField(defaultAWSAccountTypeID, "account_id", "Account ID", freshservice.FieldTypeText, false)
+	f.addField(defaultAWSAccountTypeID, "po", "PO", freshservice.FieldTypeText, false)
+	f.addField(defaultAWSAccountTypeID, "owner", "Owner", freshservice.FieldTypeText, false)
+	f.addField(defaultAWSAccountTypeID, "approved_by", "Approved By", freshservice.FieldTypeText, false)
+	f.addField(defaultAWSAccountTypeID, "environment", "Environment", freshservice.FieldTypeText, false)
+
+	f.addAssetType(defaultAzureSubscriptionTypeID, "Azure Subscription", 0)
+	for _, name := range []string{"tenant_id", "subscription_id", "po", "owner", "approver_object", "environment", "eacsp", "active", "cloudockit"} {
+		f.addField(defaultAzureSubscriptionTypeID, name, name, freshservice.FieldTypeText, false)
+	}
+
+	f.addAssetType(defaultGCPProjectTypeID, "GCP Project", 0)
+	for _, name := range []string{"project_id", "project_name", "po", "owner", "approved_by", "environment", "active"} {
+		f.addField(defaultGCPProjectTypeID, name, name, freshservice.FieldTypeText, false)
+	}
+
+	f.addAssetType(testHardwareTypeID, "Hardware", 0)
+	f.addField(testHardwareTypeID, "product", "Product", freshservice.FieldTypeText, false)
+	f.addField(testHardwareTypeID, "po_number", "PO Number", freshservice.FieldTypeText, false)
+	f.addField(testHardwareTypeID, "quantity", "Quantity", freshservice.FieldTypeNumber, false)
+	f.addField(testHardwareTypeID, "cost", "Cost", freshservice.FieldTypeDecimal, false)
+	f.addField(testHardwareTypeID, "warranty_expiry", "Warranty Expiry", freshservice.FieldTypeDate, false)
+	f.addField(testHardwareTypeID, "managed", "Managed", freshservice.FieldTypeCheckbox, false)
+	env := f.addField(testHardwareTypeID, "environment", "Environment", freshservice.FieldTypeDropdown, false)
+	env["choices"] = []interface{}{[]interface{}{"Production", 1}, []interface{}{"Test", 2}}
+
+	f.addAssetType(testLaptopTypeID, "Laptop", testHardwareTypeID)
+	f.addField(testLaptopTypeID, "serial", "Serial", freshservice.FieldTypeText, true)
+
+	f.server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(f.server.Close)
+
+	return f
+}
+
+// URL returns the API base URL of the fake, including the /api/v2 prefix
+func (f *fakeFreshservice) URL() string {
+	return f.server.URL + "/api/v2"
+}
+
+// addAssetType seeds an asset type
+func (f *fakeFreshservice) addAssetType(id int, name string, parentID int) map[string]interface{} {
+	assetType := map[string]interface{}{
+		"id":                   id,
+		"name":                 name,
+		"description":          "",
+		"parent_asset_type_id": nil,
+		"visible":              true,
+		"created_at":           f.now(),
+		"updated_at":           f.now(),
+	}
+	if parentID != 0 {
+		assetType["parent_asset_type_id"] = parentID
+	}
+	f.assetTypes[id] = assetType
+	return assetType
+}
+
+// addField seeds a custom field of an asset type
+func (f *fakeFreshservice) addField(assetTypeID int, name, label, dataType string, mandatory bool) map[string]interface{} {
+	f.nextFieldID++
+	field := map[string]interface{}{
+		"id":            f.nextFieldID,
+		"asset_type_id": assetTypeID,
+		"name":          fmt.Sprintf("%s_%d", name, assetTypeID),
+		"label":         label,
+		"desc":          "",
+		"data_type":     dataType,
+		"mandatory":     mandatory,
+		"default_field": false,
+		"choices":       []interface{}{},
+	}
+	f.fields[assetTypeID] = append(f.fields[assetTypeID], field)
+	return field
+}
+
+// now advances the fake clock and returns it formatted like the API
+func (f *fakeFreshservice) now() string {
+	f.clock = f.clock.Add(time.Minute)
+	return f.clock.Format(time.RFC3339)
+}
+
+// Asset returns a copy of the asset with the given display ID, or nil
+func (f *fakeFreshservice) Asset(displayID int) map[string]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	asset, ok := f.assets[displayID]
+	if !ok {
+		return nil
+	}
+	return copyJSON(asset)
+}
+
+// UpdateAsset changes an asset outside of Terraform. Keys of typeFields are full field names.
+func (f *fakeFreshservice) UpdateAsset(displayID int, fields map[string]interface{}, typeFields map[string]interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	asset, ok := f.assets[displayID]
+	if !ok {
+		f.t.Fatalf("fake: no asset with display ID %d", displayID)
+	}
+	for k, v := range fields {
+		asset[k] = v
+	}
+	for k, v := range typeFields {
+		asset["type_fields"].(map[string]interface{})[k] = v
+	}
+	asset["updated_at"] = f.now()
+}
+
+// DeleteAsset removes an asset outside of Terraform
+func (f *fakeFreshservice) DeleteAsset(displayID int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.assets, displayID)
+}
+
+// TrashAsset moves an asset to the trash outside of Terraform
+func (f *fakeFreshservice) TrashAsset(displayID int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if asset, ok := f.assets[displayID]; ok {
+		asset["trashed"] = true
+	}
+}
+
+// AssetType returns a copy of the asset type with the given ID, or nil
+func (f *fakeFreshservice) AssetType(id int) map[string]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	assetType, ok := f.assetTypes[id]
+	if !ok {
+		return nil
+	}
+	return copyJSON(assetType)
+}
+
+// UpdateAssetType changes an asset type outside of Terraform
+func (f *fakeFreshservice) UpdateAssetType(id int, fields map[string]interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	assetType, ok := f.assetTypes[id]
+	if !ok {
+		f.t.Fatalf("fake: no asset type with ID %d", id)
+	}
+	for k, v := range fields {
+		assetType[k] = v
+	}
+	assetType["updated_at"] = f.now()
+}
+
+// Field returns a copy of a field defined directly on an asset type, or nil
+func (f *fakeFreshservice) Field(assetTypeID, fieldID int) map[string]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	field := f.findField(assetTypeID, fieldID)
+	if field == nil {
+		return nil
+	}
+	return copyJSON(field)
+}
+
+// UpdateField changes a field of an asset type outside of Terraform
+func (f *fakeFreshservice) UpdateField(assetTypeID, fieldID int, fields map[string]interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	field := f.findField(assetTypeID, fieldID)
+	if field == nil {
+		f.t.Fatalf("fake: no field %d on asset type %d", fieldID, assetTypeID)
+	}
+	for k, v := range fields {
+		field[k] = v
+	}
+}
+
+// DeleteAssetType removes an asset type outside of Terraform
+func (f *fakeFreshservice) DeleteAssetType(id int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.assetTypes, id)
+	delete(f.fields, id)
+}
+
+// AddField adds a field to an asset type outside of Terraform
+func (f *fakeFreshservice) AddField(assetTypeID int, name, label, dataType string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.addField(assetTypeID, name, label, dataType, false)
+}
+
+// SetFieldType changes the data type of a field of an asset type, given without the
+// asset type ID suffix
+func (f *fakeFreshservice) SetFieldType(assetTypeID int, name, dataType string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, field := range f.fields[assetTypeID] {
+		if field["name"] == fmt.Sprintf("%s_%d", name, assetTypeID) {
+			field["data_type"] = dataType
+		}
+	}
+}
+
+// OmitFieldIDs makes creating a field answer without the new field, as the API sometimes does
+func (f *fakeFreshservice) OmitFieldIDs() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.omitFieldIDs = true
+}
+
+// Throttle answers the next n requests with 429 Too Many Requests
+func (f *fakeFreshservice) Throttle(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.throttled = n
+}
+
+// Fail answers the next n requests with the given status code
+func (f *fakeFreshservice) Fail(n, status int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.failures = n
+	f.failStatus = status
+}
+
+// Requests returns the requests received so far as "METHOD /path?query"
+func (f *fakeFreshservice) Requests() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]string(nil), f.requests...)
+}
+
+// CountRequests returns how many requests received so far start with prefix
+func (f *fakeFreshservice) CountRequests(prefix string) int {
+	count := 0
+	for _, r := range f.Requests() {
+		if strings.HasPrefix(r, prefix) {
+			count++
+		}
+	}
+	return count
+}
+
+var (
+	// fakeFilterFields are the asset fields the filter endpoint supports, besides type fields
+	fakeFilterFields = []string{"asset_type_id", "department_id", "location_id", "asset_state", "user_id", "agent_id", "name", "asset_tag", "created_at", "updated_at"}
+	// fakeSearchFields are the asset fields the search endpoint supports
+	fakeSearchFields = []string{"name", "asset_tag", "serial_number", "mac_addresses", "ip_addresses"}
+
+	// The writable fields of each request body
+	fakeAssetBodyFields     = []string{"name", "description", "asset_type_id", "impact", "usage_type", "asset_tag", "user_id", "location_id", "department_id", "agent_id", "group_id", "workspace_id", "type_fields"}
+	fakeAssetTypeBodyFields = []string{"name", "description", "parent_asset_type_id", "visible"}
+	fakeFieldBodyFields     = []string{"label", "desc", "data_type", "mandatory", "choices"}
+
+	fakeAssetPath           = regexp.MustCompile(`^/api/v2/assets/(\d+)$`)
+	fakeAssetActionPath     = regexp.MustCompile(`^/api/v2/assets/(\d+)/(restore|delete_forever|move_workspace)$`)
+	fakeAssetTypePath       = regexp.MustCompile(`^/api/v2/asset_types/(\d+)$`)
+	fakeAssetTypeFieldsPath = regexp.MustCompile(`^/api/v2/asset_types/(\d+)/fields$`)
+	fakeAssetTypeFieldPath  = regexp.MustCompile(`^/api/v2/asset_types/(\d+)/fields/(\d+)$`)
+)
+
+func (f *fakeFreshservice) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	entry := r.Method + " " + r.URL.Path
+	if r.URL.RawQuery != "" {
+		entry += "?" + r.URL.RawQuery
+	}
+	f.requests = append(f.requests, entry)
+
+	if user, _, ok := r.BasicAuth(); !ok || user != testAPIKey {
+		writeFakeJSON(w, http.StatusUnauthorized, map[string]interface{}{
+			"code":    "invalid_credentials",
+			"message": "You have to be logged in to perform this action.",
+		})
+		return
+	}
+
+	w.Header().Set("X-Ratelimit-Total", "140")
+	if f.throttled > 0 {
+		f.throttled--
+		w.Header().Set("Retry-After", "0")
+		w.Header().Set("X-Ratelimit-Remaining", "0")
+		writeFakeJSON(w, http.StatusTooManyRequests, map[string]interface{}{
+			"message": "You have exceeded the limit of requests per minute",
+		})
+		return
+	}
+	w.Header().Set("X-Ratelimit-Remaining", "139")
+
+	if f.failures > 0 {
+		f.failures--
+		writeFakeJSON(w, f.failStatus, map[string]interface{}{
+			"description": http.StatusText(f.failStatus),
+		})
+		return
+	}
+
+	// Only views and lists take query parameters, and only the ones they support
+	path := r.URL.Path
+	var unexpected []map[string]interface{}
+	for param, values := range r.URL.Query() {
+		if !slices.Contains(fakeQueryParams(r.Method, path), param) || (param == "include" && values[0] != "type_fields") {
+			unexpected = append(unexpected, fakeFieldError(param, "Unexpected/invalid field in request", "invalid_field"))
+		}
+	}
+	if len(unexpected) > 0 {
+		writeFakeValidationErrors(w, unexpected)
+		return
+	}
+
+	switch {
+	case path == "/api/v2/assets" && r.Method == http.MethodGet:
+		f.listAssets(w, r)
+	case path == "/api/v2/assets" && r.Method == http.MethodPost:
+		f.createAsset(w, r)
+	case fakeAssetPath.MatchString(path):
+		displayID, _ := strconv.Atoi(fakeAssetPath.FindStringSubmatch(path)[1])
+		switch r.Method {
+		case http.MethodGet:
+			f.getAsset(w, r, displayID)
+		case http.MethodPut:
+			f.updateAsset(w, r, displayID)
+		case http.MethodDelete:
+			f.trashAsset(w, displayID)
+		default:
+			writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	case fakeAssetActionPath.MatchString(path):
+		match := fakeAssetActionPath.FindStringSubmatch(path)
+		displayID, _ := strconv.Atoi(match[1])
+		switch {
+		case match[2] == "restore" && r.Method == http.MethodPut:
+			f.restoreAsset(w, displayID)
+		case match[2] == "delete_forever" && r.Method == http.MethodPut:
+			f.deleteAssetForever(w, displayID)
+		case match[2] == "move_workspace" && r.Method == http.MethodPut:
+			f.moveAssetWorkspace(w, r, displayID)
+		default:
+			writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	case path == "/api/v2/asset_types" && r.Method == http.MethodGet:
+		f.listAssetTypes(w, r)
+	case path == "/api/v2/asset_types" && r.Method == http.MethodPost:
+		f.createAssetType(w, r)
+	case fakeAssetTypePath.MatchString(path):
+		id, _ := strconv.Atoi(fakeAssetTypePath.FindStringSubmatch(path)[1])
+		switch r.Method {
+		case http.MethodGet:
+			f.getAssetType(w, id)
+		case http.MethodPut:
+			f.updateAssetType(w, r, id)
+		case http.MethodDelete:
+			f.deleteAssetType(w, id)
+		default:
+			writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	case fakeAssetTypeFieldsPath.MatchString(path):
+		id, _ := strconv.Atoi(fakeAssetTypeFieldsPath.FindStringSubmatch(path)[1])
+		switch r.Method {
+		case http.MethodGet:
+			f.listFields(w, id)
+		case http.MethodPost:
+			f.createField(w, r, id)
+		default:
+			writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	case fakeAssetTypeFieldPath.MatchString(path):
+		match := fakeAssetTypeFieldPath.FindStringSubmatch(path)
+		id, _ := strconv.Atoi(match[1])
+		fieldID, _ := strconv.Atoi(match[2])
+		switch r.Method {
+		case http.MethodPut:
+			f.updateField(w, r, id, fieldID)
+		case http.MethodDelete:
+			f.deleteField(w, id, fieldID)
+		default:
+			writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	default:
+		writeFakeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+// fakeQueryParams returns the query parameters an endpoint takes
+func fakeQueryParams(method, path string) []string {
+	switch {
+	case method != http.MethodGet:
+		return nil
+	case path == "/api/v2/assets":
+		return []string{"search", "filter", "trashed", "include", "page", "per_page"}
+	case fakeAssetPath.MatchString(path):
+		return []string{"include"}
+	case path == "/api/v2/asset_types":
+		return []string{"page", "per_page"}
+	}
+	return nil
+}
+
+// fakeAssetView returns an asset as views and lists return it: without type fields,
+// unless include=type_fields is given
+func fakeAssetView(r *http.Request, asset map[string]interface{}) map[string]interface{} {
+	if r.URL.Query().Get("include") == "type_fields" {
+		return asset
+	}
+	view := maps.Clone(asset)
+	delete(view, "type_fields")
+	return view
+}
+
+func (f *fakeFreshservice) listAssets(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	trashed := query.Get("trashed") == "true"
+
+	if query.Has("search") && query.Has("filter") {
+		writeFakeValidationErrors(w, []map[string]interface{}{
+			fakeFieldError("search", "It can't be combined with filter", "invalid_value"),
+		})
+		return
+	}
+
+	var expr fakeQueryExpr
+	for _, param := range []string{"search", "filter"} {
+		raw := query.Get(param)
+		if raw == "" {
+			continue
+		}
+		parsed, err := parseFakeQuery(raw)
+		if err == nil && param == "filter" {
+			err = f.checkFilterFields(parsed)
+		}
+		if err == nil && param == "search" {
+			parsed, err = checkSearchQuery(parsed)
+		}
+		if err != nil {
+			writeFakeJSON(w, http.StatusBadRequest, map[string]interface{}{
+				"description": "Validation failed",
+				"errors": []map[string]interface{}{
+					{"field": param, "message": err.Error(), "code": "invalid_value"},
+				},
+			})
+			return
+		}
+		expr = parsed
+	}
+
+	ids := make([]int, 0, len(f.assets))
+	for id := range f.assets {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	var matches []interface{}
+	for _, id := range ids {
+		asset := f.assets[id]
+		if isTrashed := asset["trashed"] == true; isTrashed != trashed {
+			continue
+		}
+		if expr != nil && !expr.match(asset) {
+			continue
+		}
+		matches = append(matches, fakeAssetView(r, asset))
+	}
+
+	f.writePage(w, r, "assets", matches)
+}
+
+// checkFilterFields rejects filter queries on fields the filter endpoint doesn't support
+func (f *fakeFreshservice) checkFilterFields(expr fakeQueryExpr) error {
+	switch e := expr.(type) {
+	case fakeQueryAnd:
+		for _, sub := range e {
+			if err := f.checkFilterFields(sub); err != nil {
+				return err
+			}
+		}
+	case fakeQueryOr:
+		for _, sub := range e {
+			if err := f.checkFilterFields(sub); err != nil {
+				return err
+			}
+		}
+	case fakeQueryTerm:
+		if slices.Contains(fakeFilterFields, e.field) {
+			return nil
+		}
+		for _, fields := range f.fields {
+			for _, field := range fields {
+				if field["name"] == e.field {
+					return nil
+				}
+			}
+		}
+		return fmt.Errorf("%s is not a supported filter field", e.field)
+	}
+	return nil
+}
+
+// checkSearchQuery rejects search queries the search endpoint doesn't support. It takes
+// a single field:'value' condition, matched case-insensitively and partially.
+func checkSearchQuery(expr fakeQueryExpr) (fakeQueryExpr, error) {
+	term, ok := expr.(fakeQueryTerm)
+	if !ok {
+		return nil, fmt.Errorf("search supports a single condition")
+	}
+	if !slices.Contains(fakeSearchFields, term.field) {
+		return nil, fmt.Errorf("%s is not a supported search field", term.field)
+	}
+	if term.operator != 0 || !term.quoted {
+		return nil, fmt.Errorf("%s must be compared to a quoted value", term.field)
+	}
+	return fakeSearchTerm(term), nil
+}
+
+// writePage writes one page of a list response, with a Link header when more pages follow
+func (f *fakeFreshservice) writePage(w http.ResponseWriter, r *http.Request, key string, items []interface{}) {
+	query := r.URL.Query()
+	perPage := 30
+	if v, err := strconv.Atoi(query.Get("per_page")); err == nil && v > 0 {
+		perPage = v
+	}
+	if perPage > 100 {
+		writeFakeError(w, http.StatusBadRequest, "per_page must be at most 100")
+		return
+	}
+	page := 1
+	if v, err := strconv.Atoi(query.Get("page")); err == nil && v > 0 {
+		page = v
+	}
+
+	start := (page - 1) * perPage
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + perPage
+	if end > len(items) {
+		end = len(items)
+	}
+
+	if end < len(items) {
+		next := *r.URL
+		values := next.Query()
+		values.Set("page", strconv.Itoa(page+1))
+		next.RawQuery = values.Encode()
+		w.Header().Set("Link", fmt.Sprintf(`<%s%s>; rel="next"`, f.server.URL, next.RequestURI()))
+	}
+
+	pageItems := items[start:end]
+	if pageItems == nil {
+		pageItems = []interface{}{}
+	}
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{key: pageItems})
+}
+
+func (f *fakeFreshservice) getAsset(w http.ResponseWriter, r *http.Request, displayID int) {
+	asset, ok := f.assets[displayID]
+	if !ok || asset["trashed"] == true {
+		writeFakeError(w, http.StatusNotFound, "Record not found")
+		return
+	}
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{"asset": fakeAssetView(r, asset)})
+}
+
+func (f *fakeFreshservice) createAsset(w http.ResponseWriter, r *http.Request) {
+	body, ok := f.decodeBody(w, r, fakeAssetBodyFields)
+	if !ok {
+		return
+	}
+
+	var errs []map[string]interface{}
+	if name, _ := body["name"].(string); name == "" {
+		errs = append(errs, fakeFieldError("name", "It should not be blank", "missing_field"))
+	}
+	assetTypeID := fakeInt(body["asset_type_id"])
+	if _, ok := f.assetTypes[assetTypeID]; !ok {
+		errs = append(errs, fakeFieldError("asset_type_id", "It should be the id of an existing asset type", "invalid_value"))
+	}
+	workspaceID := testITWorkspaceID
+	if v, ok := body["workspace_id"]; ok {
+		workspaceID = fakeInt(v)
+		if _, ok := fakeWorkspaces[workspaceID]; !ok {
+			errs = append(errs, fakeFieldError("workspace_id", "It should be the id of an existing workspace", "invalid_value"))
+		}
+	}
+	typeFields, _ := body["type_fields"].(map[string]interface{})
+	if len(errs) == 0 {
+		errs = append(errs, f.validateTypeFields(assetTypeID, typeFields, true)...)
+	}
+	if len(errs) > 0 {
+		writeFakeValidationErrors(w, errs)
+		return
+	}
+
+	f.nextID++
+	displayID := f.nextDisplay
+	f.nextDisplay++
+	now := f.now()
+
+	asset := map[string]interface{}{
+		"id":            f.nextID,
+		"display_id":    displayID,
+		"name":          body["name"],
+		"description":   "",
+		"asset_type_id": assetTypeID,
+		"impact":        "low",
+		"author_type":   "User",
+		"usage_type":    "permanent",
+		"asset_tag":     fmt.Sprintf("ASSET-%d", displayID),
+		"user_id":       nil,
+		"location_id":   nil,
+		"department_id": nil,
+		"agent_id":      nil,
+		"group_id":      nil,
+		"assigned_on":   nil,
+		"created_at":    now,
+		"updated_at":    now,
+		"workspace_id":  workspaceID,
+		"type_fields":   f.defaultTypeFields(assetTypeID),
+	}
+	f.applyAssetFields(asset, body)
+	f.assets[displayID] = asset
+
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{"asset": asset})
+}
+
+func (f *fakeFreshservice) updateAsset(w http.ResponseWriter, r *http.Request, displayID int) {
+	asset, ok := f.assets[displayID]
+	if !ok || asset["trashed"] == true {
+		writeFakeError(w, http.StatusNotFound, "Record not found")
+		return
+	}
+
+	body, ok := f.decodeBody(w, r, fakeAssetBodyFields)
+	if !ok {
+		return
+	}
+
+	var errs []map[string]interface{}
+	if name, ok := body["name"]; ok && name == "" {
+		errs = append(errs, fakeFieldError("name", "It should not be blank", "missing_field"))
+	}
+	if v, ok := body["asset_type_id"]; ok && fakeInt(v) != fakeInt(asset["asset_type_id"]) {
+		errs = append(errs, fakeFieldError("asset_type_id", "It cannot be changed", "invalid_value"))
+	}
+	if v, ok := body["workspace_id"]; ok && fakeInt(v) != fakeInt(asset["workspace_id"]) {
+		errs = append(errs, fakeFieldError("workspace_id", "It can only be changed with move_workspace", "invalid_value"))
+	}
+	typeFields, _ := body["type_fields"].(map[string]interface{})
+	errs = append(errs, f.validateTypeFields(fakeInt(asset["asset_type_id"]), typeFields, false)...)
+	if len(errs) > 0 {
+		writeFakeValidationErrors(w, errs)
+		return
+	}
+
+	f.applyAssetFields(asset, body)
+	asset["updated_at"] = f.now()
+
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{"asset": asset})
+}
+
+// applyAssetFields copies writable fields of a request body onto an asset, merging type fields
+func (f *fakeFreshservice) applyAssetFields(asset, body map[string]interface{}) {
+	for key, value := range body {
+		switch key {
+		case "name", "description", "impact", "usage_type", "asset_tag", "user_id", "location_id",
+			"department_id", "agent_id", "group_id", "assigned_on":
+			asset[key] = value
+		case "type_fields":
+			typeFields := asset["type_fields"].(map[string]interface{})
+			for k, v := range value.(map[string]interface{}) {
+				typeFields[k] = v
+			}
+		}
+	}
+}
+
+func (f *fakeFreshservice) trashAsset(w http.ResponseWriter, displayID int) {
+	asset, ok := f.assets[displayID]
+	if !ok || asset["trashed"] == true {
+		writeFakeError(w, http.StatusNotFound, "Record not found")
+		return
+	}
+	asset["trashed"] = true
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeFreshservice) moveAssetWorkspace(w http.ResponseWriter, r *http.Request, displayID int) {
+	asset, ok := f.assets[displayID]
+	if !ok || asset["trashed"] == true {
+		writeFakeError(w, http.StatusNotFound, "Record not found")
+		return
+	}
+
+	body, ok := f.decodeBody(w, r, []string{"workspace_id"})
+	if !ok {
+		return
+	}
+
+	workspaceID := fakeInt(body["workspace_id"])
+	if _, ok := fakeWorkspaces[workspaceID]; !ok {
+		writeFakeValidationErrors(w, []map[string]interface{}{
+			fakeFieldError("workspace_id", "It should be the id of an existing workspace", "invalid_value"),
+		})
+		return
+	}
+
+	asset["workspace_id"] = workspaceID
+	asset["updated_at"] = f.now()
+
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{"asset": asset})
+}
+
+func (f *fakeFreshservice) restoreAsset(w http.ResponseWriter, displayID int) {
+	asset, ok := f.assets[displayID]
+	if !ok || asset["trashed"] != true {
+		writeFakeError(w, http.StatusNotFound, "Record not found")
+		return
+	}
+	delete(asset, "trashed")
+	asset["updated_at"] = f.now()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeFreshservice) deleteAssetForever(w http.ResponseWriter, displayID int) {
+	asset, ok := f.assets[displayID]
+	if !ok || asset["trashed"] != true {
+		writeFakeError(w, http.StatusNotFound, "Record not found")
+		return
+	}
+	delete(f.assets, displayID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// defaultTypeFields returns the type fields of a new asset, all set to null
+func (f *fakeFreshservice) defaultTypeFields(assetTypeID int) map[string]interface{} {
+	typeFields := make(map[string]interface{})
+	for _, field := range f.typeFieldDefinitions(assetTypeID) {
+		typeFields[field["name"].(string)] = nil
+	}
+	return typeFields
+}
+
+// typeFieldDefinitions returns the fields of an asset type, including inherited ones
+func (f *fakeFreshservice) typeFieldDefinitions(assetTypeID int) []map[string]interface{} {
+	var fields []map[string]interface{}
+	for id := assetTypeID; id != 0; {
+		fields = append(fields, f.fields[id]...)
+		assetType, ok := f.assetTypes[id]
+		if !ok {
+			break
+		}
+		id = fakeInt(assetType["parent_asset_type_id"])
+	}
+	return fields
+}
+
+// validateTypeFields checks type field names and values against the asset type like the API does
+func (f *fakeFreshservice) validateTypeFields(assetTypeID int, typeFields map[string]interface{}, create bool) []map[string]interface{} {
+	definitions := make(map[string]map[string]interface{})
+	for _, field := range f.typeFieldDefinitions(assetTypeID) {
+		definitions[field["name"].(string)] = field
+	}
+
+	var errs []map[string]interface{}
+	names := make([]string, 0, len(typeFields))
+	for name := range typeFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := typeFields[name]
+		field, ok := definitions[name]
+		if !ok {
+			errs = append(errs, fakeFieldError(name, "Unexpected/invalid field in request", "invalid_field"))
+			continue
+		}
+		if value == nil {
+			continue
+		}
+
+		var valid bool
+		switch field["data_type"] {
+		case freshservice.FieldTypeNumber:
+			n, ok := value.(float64)
+			valid = ok && n == float64(int64(n))
+		case freshservice.FieldTypeDecimal:
+			_, valid = value.(float64)
+		case freshservice.FieldTypeCheckbox:
+			_, valid = value.(bool)
+		case freshservice.FieldTypeDate:
+			s, ok := value.(string)
+			dateField := freshservice.AssetTypeField{DataType: freshservice.FieldTypeDate}
+			_, err := dateField.ConvertValue(s)
+			valid = ok && err == nil
+		case freshservice.FieldTypeDropdown:
+			s, ok := value.(string)
+			field := freshservice.AssetTypeField{Choices: field["choices"].([]interface{})}
+			valid = ok && slices.Contains(field.ChoiceValues(), s)
+		default:
+			_, valid = value.(string)
+		}
+		if !valid {
+			errs = append(errs, fakeFieldError(name, fmt.Sprintf("It should be a valid %s", field["data_type"]), "datatype_mismatch"))
+		}
+	}
+
+	if create {
+		for name, field := range definitions {
+			if field["mandatory"] == true && typeFields[name] == nil {
+				errs = append(errs, fakeFieldError(name, "It should not be blank", "missing_field"))
+			}
+		}
+	}
+
+	return errs
+}
+
+func (f *fakeFreshservice) listAssetTypes(w http.ResponseWriter, r *http.Request) {
+	ids := make([]int, 0, len(f.assetTypes))
+	for id := range f.assetTypes {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	items := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		items = append(items, f.assetTypes[id])
+	}
+
+	f.writePage(w, r, "asset_types", items)
+}
+
+func (f *fakeFreshservice) getAssetType(w http.ResponseWriter, id int) {
+	assetType, ok := f.assetTypes[id]
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "Record not found")
+		return
+	}
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{"asset_type": assetType})
+}
+
+func (f *fakeFreshservice) createAssetType(w http.ResponseWriter, r *http.Request) {
+	body, ok := f.decodeBody(w, r, fakeAssetTypeBodyFields)
+	if !ok {
+		return
+	}
+
+	if errs := f.validateAssetType(body, 0); len(errs) > 0 {
+		writeFakeValidationErrors(w, errs)
+		return
+	}
+
+	f.nextID++
+	assetType := f.addAssetType(f.nextID, body["name"].(string), fakeInt(body["parent_asset_type_id"]))
+	if description, ok := body["description"].(string); ok {
+		assetType["description"] = description
+	}
+	if visible, ok := body["visible"].(bool); ok {
+		assetType["visible"] = visible
+	}
+
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{"asset_type": assetType})
+}
+
+func (f *fakeFreshservice) updateAssetType(w http.ResponseWriter, r *http.Request, id int) {
+	assetType, ok := f.assetTypes[id]
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "Record not found")
+		return
+	}
+
+	body, ok := f.decodeBody(w, r, fakeAssetTypeBodyFields)
+	if !ok {
+		return
+	}
+
+	if errs := f.validateAssetType(body, id); len(errs) > 0 {
+		writeFakeValidationErrors(w, errs)
+		return
+	}
+
+	for _, key := range []string{"name", "description", "visible", "parent_asset_type_id"} {
+		if value, ok := body[key]; ok {
+			assetType[key] = value
+		}
+	}
+	assetType["updated_at"] = f.now()
+
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{"asset_type": assetType})
+}
+
+// validateAssetType checks an asset type request body; id is 0 when creating
+func (f *fakeFreshservice) validateAssetType(body map[string]interface{}, id int) []map[string]interface{} {
+	var errs []map[string]interface{}
+
+	name, hasName := body["name"].(string)
+	if (id == 0 || hasName) && name == "" {
+		errs = append(errs, fakeFieldError("name", "It should not be blank", "missing_field"))
+	}
+	for otherID, other := range f.assetTypes {
+		if otherID != id && hasName && strings.EqualFold(other["name"].(string), name) {
+			errs = append(errs, fakeFieldError("name", "It should be a unique value", "duplicate_value"))
+		}
+	}
+	if parentID := fakeInt(body["parent_asset_type_id"]); parentID != 0 {
+		if _, ok := f.assetTypes[parentID]; !ok {
+			errs = append(errs, fakeFieldError("parent_asset_type_id", "It should be the id of an existing asset type", "invalid_value"))
+		}
+	}
+
+	return errs
+}
+
+func (f *fakeFreshservice) deleteAssetType(w http.ResponseWriter, id int) {
+	if _, ok := f.assetTypes[id]; !ok {
+		writeFakeError(w, http.StatusNotFound, "Record not found")
+		return
+	}
+	delete(f.assetTypes, id)
+	delete(f.fields, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeFreshservice) listFields(w http.ResponseWriter, id int) {
+	if _, ok := f.assetTypes[id]; !ok {
+		writeFakeError(w, http.StatusNotFound, "Record not found")
+		return
+	}
+
+	groups := []interface{}{
+		map[string]interface{}{
+			"id":           1,
+			"field_header": "General",
+			"fields": []interface{}{
+				map[string]interface{}{"id": 1, "name": "name", "label": "Name", "data_type": freshservice.FieldTypeText, "mandatory": true, "default_field": true, "choices": []interface{}{}},
+				map[string]interface{}{"id": 2, "name": "description", "label": "Description", "data_type": freshservice.FieldTypeParagraph, "mandatory": false, "default_field": true, "choices": []interface{}{}},
+			},
+		},
+	}
+
+	// Fields are grouped by the asset type that defines them, inherited ones included
+	for typeID := id; typeID != 0; {
+		assetType, ok := f.assetTypes[typeID]
+		if !ok {
+			break
+		}
+		fields := make([]interface{}, 0, len(f.fields[typeID]))
+		for _, field := range f.fields[typeID] {
+			fields = append(fields, field)
+		}
+		groups = append(groups, map[string]interface{}{
+			"id":           typeID,
+			"field_header": assetType["name"],
+			"fields":       fields,
+		})
+		typeID = fakeInt(assetType["parent_asset_type_id"])
+	}
+
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{"asset_type_fields": groups})
+}
+
+func (f *fakeFreshservice) createField(w http.ResponseWriter, r *http.Request, id int) {
+	if _, ok := f.assetTypes[id]; !ok {
+		writeFakeError(w, http.StatusNotFound, "Record not found")
+		return
+	}
+
+	body, ok := f.decodeBody(w, r, fakeFieldBodyFields)
+	if !ok {
+		return
+	}
+
+	label, _ := body["label"].(string)
+	if label == "" {
+		writeFakeValidationErrors(w, []map[string]interface{}{fakeFieldError("label", "It should not be blank", "missing_field")})
+		return
+	}
+	dataType, _ := body["data_type"].(string)
+	if dataType == "" {
+		dataType = freshservice.FieldTypeText
+	}
+
+	name := strings.Trim(regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(strings.ToLower(label), "_"), "_")
+	for _, existing := range f.fields[id] {
+		if existing["name"] == fmt.Sprintf("%s_%d", name, id) {
+			writeFakeValidationErrors(w, []map[string]interface{}{fakeFieldError("label", "It should be a unique value", "duplicate_value")})
+			return
+		}
+	}
+
+	field := f.addField(id, name, label, dataType, body["mandatory"] == true)
+	f.applyFieldBody(field, body)
+
+	if f.omitFieldIDs {
+		writeFakeJSON(w, http.StatusOK, map[string]interface{}{})
+		return
+	}
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{"asset_type_field": field})
+}
+
+func (f *fakeFreshservice) updateField(w http.ResponseWriter, r *http.Request, id, fieldID int) {
+	field := f.findField(id, fieldID)
+	if field == nil {
+		writeFakeError(w, http.StatusNotFound, "Record not found")
+		return
+	}
+
+	body, ok := f.decodeBody(w, r, fakeFieldBodyFields)
+	if !ok {
+		return
+	}
+	if dataType, ok := body["data_type"]; ok && dataType != field["data_type"] {
+		writeFakeValidationErrors(w, []map[string]interface{}{fakeFieldError("data_type", "It cannot be changed", "invalid_value")})
+		return
+	}
+
+	f.applyFieldBody(field, body)
+
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{"asset_type_field": field})
+}
+
+// applyFieldBody copies writable field properties from a request body
+func (f *fakeFreshservice) applyFieldBody(field, body map[string]interface{}) {
+	for _, key := range []string{"label", "desc", "mandatory"} {
+		if value, ok := body[key]; ok {
+			field[key] = value
+		}
+	}
+	if choices, ok := body["choices"].([]interface{}); ok {
+		pairs := make([]interface{}, 0, len(choices))
+		for i, choice := range choices {
+			pairs = append(pairs, []interface{}{choice, i + 1})
+		}
+		field["choices"] = pairs
+	}
+}
+
+func (f *fakeFreshservice) deleteField(w http.ResponseWriter, id, fieldID int) {
+	if f.findField(id, fieldID) == nil {
+		writeFakeError(w, http.StatusNotFound, "Record not found")
+		return
+	}
+
+	fields := f.fields[id][:0]
+	for _, field := range f.fields[id] {
+		if fakeInt(field["id"]) != fieldID {
+			fields = append(fields, field)
+		}
+	}
+	f.fields[id] = fields
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// findField returns a field defined directly on an asset type, or nil
+func (f *fakeFreshservice) findField(id, fieldID int) map[string]interface{} {
+	for _, field := range f.fields[id] {
+		if fakeInt(field["id"]) == fieldID {
+			return field
+		}
+	}
+	return nil
+}
+
+// decodeBody decodes a JSON request body, answering 400 when it is invalid or has
+// fields other than the allowed ones
+func (f *fakeFreshservice) decodeBody(w http.ResponseWriter, r *http.Request, allowed []string) (map[string]interface{}, bool) {
+	var body map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeFakeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid JSON: %s", err))
+		return nil, false
+	}
+
+	var errs []map[string]interface{}
+	for _, key := range slices.Sorted(maps.Keys(body)) {
+		if !slices.Contains(allowed, key) {
+			errs = append(errs, fakeFieldError(key, "Unexpected/invalid field in request", "invalid_field"))
+		}
+	}
+	if len(errs) > 0 {
+		writeFakeValidationErrors(w, errs)
+		return nil, false
+	}
+	return body, true
+}
+
+// fakeQueryExpr is a parsed search or filter query
+type fakeQueryExpr interface {
+	match(asset map[string]interface{}) bool
+}
+
+type fakeQueryAnd []fakeQueryExpr
+
+func (e fakeQueryAnd) match(asset map[string]interface{}) bool {
+	for _, expr := range e {
+		if !expr.match(asset) {
+			return false
+		}
+	}
+	return true
+}
+
+type fakeQueryOr []fakeQueryExpr
+
+func (e fakeQueryOr) match(asset map[string]interface{}) bool {
+	for _, expr := range e {
+		if expr.match(asset) {
+			return true
+		}
+	}
+	return false
+}
+
+// fakeQueryTerm is a single field:value, field:>value or field:<value condition
+type fakeQueryTerm struct {
+	field    string
+	operator byte
+	value    string
+	quoted   bool
+}
+
+func (e fakeQueryTerm) match(asset map[string]interface{}) bool {
+	value, ok := asset[e.field]
+	if !ok {
+		value = asset["type_fields"].(map[string]interface{})[e.field]
+	}
+
+	if !e.quoted && e.value == "null" {
+		return value == nil
+	}
+	if value == nil {
+		return false
+	}
+
+	actual := freshservice.FormatFieldValue(value)
+	switch e.operator {
+	case '>', '<':
+		a, errA := strconv.ParseFloat(actual, 64)
+		b, errB := strconv.ParseFloat(e.value, 64)
+		cmp := strings.Compare(actual, e.value)
+		if errA == nil && errB == nil {
+			cmp = 0
+			if a < b {
+				cmp = -1
+			} else if a > b {
+				cmp = 1
+			}
+		}
+		if e.operator == '>' {
+			return cmp >= 0
+		}
+		return cmp <= 0
+	}
+
+	return actual == e.value
+}
+
+// fakeSearchTerm is a search condition, matching values case-insensitively and partially
+type fakeSearchTerm fakeQueryTerm
+
+func (e fakeSearchTerm) match(asset map[string]interface{}) bool {
+	values, ok := asset[e.field].([]interface{})
+	if !ok {
+		values = []interface{}{asset[e.field]}
+	}
+	for _, value := range values {
+		if value != nil && strings.Contains(strings.ToLower(freshservice.FormatFieldValue(value)), strings.ToLower(e.value)) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseFakeQuery parses the subset of the Freshservice query language the provider
+// generates: field:value terms combined with AND, OR and parentheses
+func parseFakeQuery(raw string) (fakeQueryExpr, error) {
+	raw = strings.TrimSpace(raw)
+	if len(raw) < 2 || raw[0] != '"' || raw[len(raw)-1] != '"' {
+		return nil, fmt.Errorf("query must be enclosed in double quotes")
+	}
+
+	p := &fakeQueryParser{input: raw[1 : len(raw)-1]}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos != len(p.input) {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.input[p.pos:], p.pos)
+	}
+	return expr, nil
+}
+
+type fakeQueryParser struct {
+	input string
+	pos   int
+}
+
+func (p *fakeQueryParser) skipSpaces() {
+	for p.pos < len(p.input) && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *fakeQueryParser) keyword(word string) bool {
+	p.skipSpaces()
+	if strings.HasPrefix(p.input[p.pos:], word+" ") {
+		p.pos += len(word) + 1
+		return true
+	}
+	return false
+}
+
+func (p *fakeQueryParser) parseOr() (fakeQueryExpr, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	exprs := fakeQueryOr{first}
+	for p.keyword("OR") {
+		next, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, next)
+	}
+	if len(exprs) == 1 {
+		return first, nil
+	}
+	return exprs, nil
+}
+
+func (p *fakeQueryParser) parseAnd() (fakeQueryExpr, error) {
+	first, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	exprs := fakeQueryAnd{first}
+	for p.keyword("AND") {
+		next, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, next)
+	}
+	if len(exprs) == 1 {
+		return first, nil
+	}
+	return exprs, nil
+}
+
+func (p *fakeQueryParser) parsePrimary() (fakeQueryExpr, error) {
+	p.skipSpaces()
+	if p.pos < len(p.input) && p.input[p.pos] == '(' {
+		p.pos++
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if p.pos >= len(p.input) || p.input[p.pos] != ')' {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return expr, nil
+	}
+
+	colon := strings.IndexByte(p.input[p.pos:], ':')
+	if colon <= 0 {
+		return nil, fmt.Errorf("expected field:value at position %d", p.pos)
+	}
+	term := fakeQueryTerm{field: p.input[p.pos : p.pos+colon]}
+	p.pos += colon + 1
+
+	if p.pos < len(p.input) && (p.input[p.pos] == '>' || p.input[p.pos] == '<') {
+		term.operator = p.input[p.pos]
+		p.pos++
+	}
+
+	if p.pos < len(p.input) && p.input[p.pos] == '\'' {
+		term.quoted = true
+		p.pos++
+		var b strings.Builder
+		for {
+			if p.pos >= len(p.input) {
+				return nil, fmt.Errorf("unterminated value for %s", term.field)
+			}
+			c := p.input[p.pos]
+			p.pos++
+			if c == '\\' && p.pos < len(p.input) {
+				b.WriteByte(p.input[p.pos])
+				p.pos++
+				continue
+			}
+			if c == '\'' {
+				break
+			}
+			b.WriteByte(c)
+		}
+		term.value = b.String()
+		return term, nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.input) && p.input[p.pos] != ' ' && p.input[p.pos] != ')' {
+		p.pos++
+	}
+	term.value = p.input[start:p.pos]
+	if term.value == "" {
+		return nil, fmt.Errorf("missing value for %s", term.field)
+	}
+	return term, nil
+}
+
+// fakeFieldError builds a field level error of an API error payload
+func fakeFieldError(field, message, code string) map[string]interface{} {
+	return map[string]interface{}{"field": field, "message": message, "code": code}
+}
+
+// writeFakeValidationErrors writes a 400 response with field level errors
+func writeFakeValidationErrors(w http.ResponseWriter, errs []map[string]interface{}) {
+	writeFakeJSON(w, http.StatusBadRequest, map[string]interface{}{
+		"description": "Validation failed",
+		"errors":      errs,
+	})
+}
+
+// writeFakeError writes an error response without field level errors
+func writeFakeError(w http.ResponseWriter, status int, description string) {
+	writeFakeJSON(w, status, map[string]interface{}{
+		"description": description,
+		"errors":      []interface{}{},
+	})
+}
+
+// writeFakeJSON writes a JSON response
+func writeFakeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// fakeInt converts a JSON number or Go int to an int, returning 0 for anything else
+func fakeInt(value interface{}) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	case string:
+		n, _ := strconv.Atoi(v)
+		return n
+	}
+	return 0
+}
+
+// copyJSON returns a deep copy of a JSON object
+func copyJSON(value map[string]interface{}) map[string]interface{} {
+	data, _ := json.Marshal(value)
+	var result map[string]interface{}
+	_ = json.Unmarshal(data, &result)
+	return result
+}
+
+// TestFakeFreshservice_rejectsUnsupportedRequests keeps the fake from accepting requests
+// the Freshservice API would refuse
+func TestFakeFreshservice_rejectsUnsupportedRequests(t *testing.T) {
+	f := newFakeFreshservice(t)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{"search on display_id", http.MethodGet, `/assets?search="display_id:'1'"`, "", http.StatusBadRequest},
+		{"search with AND", http.MethodGet, `/assets?search="name:'a' AND asset_tag:'b'"`, "", http.StatusBadRequest},
+		{"search with a range", http.MethodGet, `/assets?search="name:>'a'"`, "", http.StatusBadRequest},
+		{"search and filter", http.MethodGet, `/assets?search="name:'a'"&filter="name:'a'"`, "", http.StatusBadRequest},
+		{"filter on an unknown field", http.MethodGet, `/assets?filter="serial_number:'a'"`, "", http.StatusBadRequest},
+		{"unknown list parameter", http.MethodGet, "/asset_types?trashed=true", "", http.StatusBadRequest},
+		{"unknown parameter on a single asset", http.MethodGet, "/assets/1?trashed=true", "", http.StatusBadRequest},
+		{"unknown include", http.MethodGet, "/assets?include=fields", "", http.StatusBadRequest},
+		{"unknown body field", http.MethodPost, "/asset_types", `{"name":"Printer","icon":"printer"}`, http.StatusBadRequest},
+		{"unknown endpoint", http.MethodGet, "/tickets", "", http.StatusNotFound},
+		{"search on name", http.MethodGet, `/assets?search="name:'laptop'"&page=1&per_page=30`, "", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, f.URL()+tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.SetBasicAuth(testAPIKey, "X")
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.want {
+				t.Errorf("got status %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestFakeFreshservice_typeFieldsOnlyWhenIncluded(t *testing.T) {
+	f := newFakeFreshservice(t)
+	f.assets[1] = map[string]interface{}{"id": 1, "display_id": 1, "name": "Laptop", "type_fields": map[string]interface{}{"serial_26": "SN-1"}}
+
+	for path, want := range map[string]bool{
+		"/assets/1":                     false,
+		"/assets/1?include=type_fields": true,
+		"/assets":                       false,
+		"/assets?include=type_fields":   true,
+	} {
+		req, err := http.NewRequest(http.MethodGet, f.URL()+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.SetBasicAuth(testAPIKey, "X")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var body struct {
+			Asset  map[string]interface{}   `json:"asset"`
+			Assets []map[string]interface{} `json:"assets"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		asset := body.Asset
+		if len(body.Assets) == 1 {
+			asset = body.Assets[0]
+		}
+		if _, ok := asset["type_fields"]; ok != want {
+			t.Errorf("%s: type fields returned: %t, want %t", path, ok, want)
+		}
+	}
+}
@@ -0,0 +1,426 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Filter operators supported by the query builder
+const (
+	filterOpEquals  = "eq"
+	filterOpGreater = "gte"
+	filterOpLess    = "lte"
+	filterOpBetween = "between"
+	filterOpIn      = "in"
+	filterOpIsNull  = "is_null"
+)
+
+// Value types used to decide how filter values are rendered
+const (
+	filterValueString = "string"
+	filterValueNumber = "number"
+	filterValueDate   = "date"
+	filterValueBool   = "boolean"
+)
+
+// filterDateFields are standard asset fields compared as dates
+var filterDateFields = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+}
+
+// filterNumberFields are standard asset fields compared as numbers
+var filterNumberFields = map[string]bool{
+	"asset_type_id": true,
+	"department_id": true,
+	"location_id":   true,
+	"user_id":       true,
+	"agent_id":      true,
+	"group_id":      true,
+}
+
+// filterCondition is a single predicate of a Freshservice filter query
+type filterCondition struct {
+	Field     string
+	Operator  string
+	Values    []string
+	ValueType string
+}
+
+// filterGroup combines conditions and nested groups with AND (match "all") or OR (match "any")
+type filterGroup struct {
+	Match      string
+	Conditions []filterCondition
+	Groups     []filterGroup
+}
+
+// render builds the query expression for the group, or "" when the group is empty
+func (g filterGroup) render() (string, error) {
+	parts, err := g.renderParts()
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(parts, g.joiner()), nil
+}
+
+// renderParts renders every condition and nested group of the group.
+// Nested groups with more than one part are wrapped in parentheses.
+func (g filterGroup) renderParts() ([]string, error) {
+	var parts []string
+
+	for _, condition := range g.Conditions {
+		part, err := condition.render()
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+	}
+
+	for _, group := range g.Groups {
+		nested, err := group.renderParts()
+		if err != nil {
+			return nil, err
+		}
+		switch len(nested) {
+		case 0:
+		case 1:
+			parts = append(parts, nested[0])
+		default:
+			parts = append(parts, "("+strings.Join(nested, group.joiner())+")")
+		}
+	}
+
+	return parts, nil
+}
+
+// joiner returns the boolean operator placed between the parts of the group
+func (g filterGroup) joiner() string {
+	if g.Match == "any" {
+		return " OR "
+	}
+	return " AND "
+}
+
+// render builds the query expression for a single condition
+func (c filterCondition) render() (string, error) {
+	if c.Field == "" {
+		return "", fmt.Errorf("filter condition is missing a field")
+	}
+	// The filter endpoint does not support display_id
+	if c.Field == "display_id" {
+		return "", fmt.Errorf("display_id is not a supported filter field, set display_id on the data source instead")
+	}
+
+	valueType := c.ValueType
+	if valueType == "" {
+		valueType = defaultFilterValueType(c.Field)
+	}
+
+	operator := c.Operator
+	if operator == "" {
+		operator = filterOpEquals
+	}
+
+	renderValue := func(index int) (string, error) {
+		if index >= len(c.Values) {
+			return "", fmt.Errorf("filter condition on %q with operator %q needs %d value(s)", c.Field, operator, index+1)
+		}
+		return formatFilterValue(c.Values[index], valueType)
+	}
+
+	switch operator {
+	case filterOpIsNull:
+		return fmt.Sprintf("%s:null", c.Field), nil
+
+	case filterOpEquals, filterOpGreater, filterOpLess:
+		if len(c.Values) != 1 {
+			return "", fmt.Errorf("filter condition on %q with operator %q needs exactly one value", c.Field, operator)
+		}
+		value, err := renderValue(0)
+		if err != nil {
+			return "", err
+		}
+		switch operator {
+		case filterOpGreater:
+			if valueType != filterValueNumber && valueType != filterValueDate {
+				return "", fmt.Errorf("operator %q on %q requires a number or date value", operator, c.Field)
+			}
+			return fmt.Sprintf("%s:>%s", c.Field, value), nil
+		case filterOpLess:
+			if valueType != filterValueNumber && valueType != filterValueDate {
+				return "", fmt.Errorf("operator %q on %q requires a number or date value", operator, c.Field)
+			}
+			return fmt.Sprintf("%s:<%s", c.Field, value), nil
+		}
+		return fmt.Sprintf("%s:%s", c.Field, value), nil
+
+	case filterOpBetween:
+		if len(c.Values) != 2 {
+			return "", fmt.Errorf("filter condition on %q with operator %q needs exactly two values", c.Field, operator)
+		}
+		if valueType != filterValueNumber && valueType != filterValueDate {
+			return "", fmt.Errorf("operator %q on %q requires number or date values", operator, c.Field)
+		}
+		lower, err := renderValue(0)
+		if err != nil {
+			return "", err
+		}
+		upper, err := renderValue(1)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("(%s:>%s AND %s:<%s)", c.Field, lower, c.Field, upper), nil
+
+	case filterOpIn:
+		if len(c.Values) == 0 {
+			return "", fmt.Errorf("filter condition on %q with operator %q needs at least one value", c.Field, operator)
+		}
+		parts := make([]string, 0, len(c.Values))
+		for i := range c.Values {
+			value, err := renderValue(i)
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, fmt.Sprintf("%s:%s", c.Field, value))
+		}
+		if len(parts) == 1 {
+			return parts[0], nil
+		}
+		return "(" + strings.Join(parts, " OR ") + ")", nil
+	}
+
+	return "", fmt.Errorf("unsupported filter operator %q", operator)
+}
+
+// defaultFilterValueType infers the value type of standard asset fields
+func defaultFilterValueType(field string) string {
+	if filterDateFields[field] {
+		return filterValueDate
+	}
+	if filterNumberFields[field] {
+		return filterValueNumber
+	}
+	return filterValueString
+}
+
+// formatFilterValue validates a value and renders it with the quoting Freshservice expects:
+// numbers, booleans and null are bare while strings and dates are single quoted
+func formatFilterValue(value, valueType string) (string, error) {
+	switch valueType {
+	case filterValueNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "", fmt.Errorf("%q is not a valid number", value)
+		}
+		return value, nil
+	case filterValueBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("%q is not a valid boolean", value)
+		}
+		return strconv.FormatBool(b), nil
+	case filterValueDate:
+		// The filter endpoint only compares whole days, so timestamps are rejected rather than cut short
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			if _, err := time.Parse(time.RFC3339, value); err == nil {
+				return "", fmt.Errorf("%q is a timestamp, filters only compare dates in YYYY-MM-DD format", value)
+			}
+			return "", fmt.Errorf("%q is not a valid date, expected YYYY-MM-DD", value)
+		}
+		return quoteFilterValue(value), nil
+	case filterValueString, "":
+		return quoteFilterValue(value), nil
+	}
+
+	return "", fmt.Errorf("unsupported filter value type %q", valueType)
+}
+
+// quoteFilterValue wraps a string value in single quotes, escaping characters that
+// would otherwise end the value or the surrounding double quoted query
+func quoteFilterValue(value string) string {
+	return "'" + escapeSearchValue(value) + "'"
+}
+
+// wrapFilterQuery wraps a rendered expression in the double quotes required by the API
+func wrapFilterQuery(query string) string {
+	return fmt.Sprintf("\"%s\"", query)
+}
+
+// filterConditionSchema returns the schema of a condition block
+func filterConditionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "A single predicate of the filter",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"field": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Standard asset field to compare, e.g. created_at, asset_type_id or name. Exactly one of field or type_field must be set",
+				},
+				"type_field": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Custom type field to compare, without the asset type ID suffix (e.g. 'environment'). Requires asset_type_id on the filter",
+				},
+				"operator": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      filterOpEquals,
+					ValidateFunc: validation.StringInSlice([]string{filterOpEquals, filterOpGreater, filterOpLess, filterOpBetween, filterOpIn, filterOpIsNull}, false),
+					Description:  "Comparison operator: eq, gte, lte, between, in or is_null (default: eq)",
+				},
+				"values": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Values to compare against. eq, gte and lte take one value, between takes two (lower and upper bound), in takes one or more and is_null takes none",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"value_type": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringInSlice([]string{filterValueString, filterValueNumber, filterValueDate, filterValueBool}, false),
+					Description:  "How values are rendered: string, number, date or boolean. Inferred for standard fields, defaults to string for type fields",
+				},
+			},
+		},
+	}
+}
+
+// filterBlockSchema returns the schema of the filter block shared by the asset data sources
+func filterBlockSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Structured Freshservice filter query",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"match": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "all",
+					ValidateFunc: validation.StringInSlice([]string{"all", "any"}, false),
+					Description:  "Combine conditions and groups with AND (all) or OR (any) (default: all)",
+				},
+				"asset_type_id": {
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Description: "Asset type ID appended to type_field names",
+				},
+				"condition": filterConditionSchema(),
+				"group": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Nested group of conditions, rendered in parentheses",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"match": {
+								Type:         schema.TypeString,
+								Optional:     true,
+								Default:      "all",
+								ValidateFunc: validation.StringInSlice([]string{"all", "any"}, false),
+								Description:  "Combine the conditions of the group with AND (all) or OR (any) (default: all)",
+							},
+							"condition": filterConditionSchema(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// expandFilterBlock converts the filter block into a filter group.
+// fallbackAssetTypeID is used for type_field names when the block has no asset_type_id.
+func expandFilterBlock(raw []interface{}, fallbackAssetTypeID int) (*filterGroup, error) {
+	if len(raw) == 0 || raw[0] == nil {
+		return nil, nil
+	}
+	block := raw[0].(map[string]interface{})
+
+	assetTypeID := block["asset_type_id"].(int)
+	if assetTypeID == 0 {
+		assetTypeID = fallbackAssetTypeID
+	}
+
+	conditions, err := expandFilterConditions(block["condition"].([]interface{}), assetTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	group := &filterGroup{
+		Match:      block["match"].(string),
+		Conditions: conditions,
+	}
+
+	for _, rawGroup := range block["group"].([]interface{}) {
+		if rawGroup == nil {
+			continue
+		}
+		nested := rawGroup.(map[string]interface{})
+		nestedConditions, err := expandFilterConditions(nested["condition"].([]interface{}), assetTypeID)
+		if err != nil {
+			return nil, err
+		}
+		group.Groups = append(group.Groups, filterGroup{
+			Match:      nested["match"].(string),
+			Conditions: nestedConditions,
+		})
+	}
+
+	return group, nil
+}
+
+// expandFilterConditions converts condition blocks into filter conditions
+func expandFilterConditions(raw []interface{}, assetTypeID int) ([]filterCondition, error) {
+	var conditions []filterCondition
+
+	for _, rawCondition := range raw {
+		if rawCondition == nil {
+			continue
+		}
+		c := rawCondition.(map[string]interface{})
+
+		field := c["field"].(string)
+		typeField := c["type_field"].(string)
+		valueType := c["value_type"].(string)
+
+		switch {
+		case field != "" && typeField != "":
+			return nil, fmt.Errorf("filter condition must set only one of field or type_field, got %q and %q", field, typeField)
+		case typeField != "":
+			if assetTypeID == 0 {
+				return nil, fmt.Errorf("filter condition on type_field %q requires asset_type_id", typeField)
+			}
+			field = fmt.Sprintf("%s_%d", typeField, assetTypeID)
+			if valueType == "" {
+				valueType = filterValueString
+			}
+		case field == "":
+			return nil, fmt.Errorf("filter condition must set field or type_field")
+		}
+
+		var values []string
+		for _, v := range c["values"].([]interface{}) {
+			value, _ := v.(string)
+			values = append(values, value)
+		}
+
+		conditions = append(conditions, filterCondition{
+			Field:     field,
+			Operator:  c["operator"].(string),
+			Values:    values,
+			ValueType: valueType,
+		})
+	}
+
+	return conditions, nil
+}
@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestFilterConditionRender(t *testing.T) {
+	cases := []struct {
+		name      string
+		condition filterCondition
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "string equality is single quoted",
+			condition: filterCondition{Field: "name", Values: []string{"Dell laptop"}},
+			want:      "name:'Dell laptop'",
+		},
+		{
+			name:      "single quotes are escaped",
+			condition: filterCondition{Field: "name", Values: []string{"O'Brien's laptop"}},
+			want:      `name:'O\'Brien\'s laptop'`,
+		},
+		{
+			name:      "backslashes are escaped before quotes",
+			condition: filterCondition{Field: "name", Values: []string{`C:\temp'`}},
+			want:      `name:'C:\\temp\''`,
+		},
+		{
+			name:      "double quotes cannot end the query",
+			condition: filterCondition{Field: "name", Values: []string{`say "hi"`}},
+			want:      `name:'say \"hi\"'`,
+		},
+		{
+			name:      "numeric fields are bare",
+			condition: filterCondition{Field: "asset_type_id", Values: []string{"56000947175"}},
+			want:      "asset_type_id:56000947175",
+		},
+		{
+			name:      "numeric fields reject non numbers",
+			condition: filterCondition{Field: "department_id", Values: []string{"sales"}},
+			wantErr:   true,
+		},
+		{
+			name:      "date comparison",
+			condition: filterCondition{Field: "created_at", Operator: filterOpGreater, Values: []string{"2024-01-01"}},
+			want:      "created_at:>'2024-01-01'",
+		},
+		{
+			name:      "invalid date",
+			condition: filterCondition{Field: "updated_at", Operator: filterOpLess, Values: []string{"yesterday"}},
+			wantErr:   true,
+		},
+		{
+			name:      "timestamps are rejected",
+			condition: filterCondition{Field: "created_at", Operator: filterOpGreater, Values: []string{"2024-01-01T09:00:00Z"}},
+			wantErr:   true,
+		},
+		{
+			name:      "display_id is not filterable",
+			condition: filterCondition{Field: "display_id", Values: []string{"42"}},
+			wantErr:   true,
+		},
+		{
+			name:      "comparison on a string is rejected",
+			condition: filterCondition{Field: "name", Operator: filterOpGreater, Values: []string{"a"}},
+			wantErr:   true,
+		},
+		{
+			name:      "numeric range",
+			condition: filterCondition{Field: "cost_25", Operator: filterOpBetween, Values: []string{"100", "500.5"}, ValueType: filterValueNumber},
+			want:      "(cost_25:>100 AND cost_25:<500.5)",
+		},
+		{
+			name:      "range needs two values",
+			condition: filterCondition{Field: "created_at", Operator: filterOpBetween, Values: []string{"2024-01-01"}},
+			wantErr:   true,
+		},
+		{
+			name:      "null check",
+			condition: filterCondition{Field: "user_id", Operator: filterOpIsNull},
+			want:      "user_id:null",
+		},
+		{
+			name:      "in renders OR",
+			condition: filterCondition{Field: "environment_25", Operator: filterOpIn, Values: []string{"Production", "Test"}},
+			want:      "(environment_25:'Production' OR environment_25:'Test')",
+		},
+		{
+			name:      "boolean values are bare",
+			condition: filterCondition{Field: "active_25", Values: []string{"TRUE"}, ValueType: filterValueBool},
+			want:      "active_25:true",
+		},
+		{
+			name:      "unknown operator",
+			condition: filterCondition{Field: "name", Operator: "like", Values: []string{"a"}},
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.condition.render()
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFilterGroupRender(t *testing.T) {
+	group := filterGroup{
+		Match: "all",
+		Conditions: []filterCondition{
+			{Field: "asset_type_id", Values: []string{"25"}},
+		},
+		Groups: []filterGroup{
+			{
+				Match: "any",
+				Conditions: []filterCondition{
+					{Field: "department_id", Values: []string{"5"}},
+					{Field: "location_id", Operator: filterOpIsNull},
+				},
+			},
+			{
+				Match: "any",
+				Conditions: []filterCondition{
+					{Field: "created_at", Operator: filterOpGreater, Values: []string{"2024-01-01"}},
+				},
+			},
+			{Match: "all"},
+		},
+	}
+
+	got, err := group.render()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := "asset_type_id:25 AND (department_id:5 OR location_id:null) AND created_at:>'2024-01-01'"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if wrapped := wrapFilterQuery(got); wrapped != `"`+want+`"` {
+		t.Errorf("wrapFilterQuery() = %q", wrapped)
+	}
+}
+
+func TestExpandFilterBlock(t *testing.T) {
+	raw := []interface{}{
+		map[string]interface{}{
+			"match":         "any",
+			"asset_type_id": 0,
+			"condition": []interface{}{
+				map[string]interface{}{
+					"field":      "",
+					"type_field": "environment",
+					"operator":   filterOpEquals,
+					"values":     []interface{}{"Production"},
+					"value_type": "",
+				},
+			},
+			"group": []interface{}{},
+		},
+	}
+
+	if _, err := expandFilterBlock(raw, 0); err == nil {
+		t.Fatal("expected an error for type_field without asset_type_id")
+	}
+
+	group, err := expandFilterBlock(raw, 56000947175)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	got, err := group.render()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := "environment_56000947175:'Production'"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestBuildSearchQuery(t *testing.T) {
+	got := buildSearchQuery("name", "Bob's laptop")
+	want := `"name:'Bob\'s laptop'"`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
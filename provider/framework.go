@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// frameworkProvider serves the resources moved to terraform-plugin-framework. It is muxed
+// with the SDKv2 provider, which stays in charge of the provider configuration: framework
+// resources share its *Config, so the rate limit and the field cache cover both.
+//
+// Only freshservice_asset_type has moved so far. The asset resources and the data sources
+// stay on SDKv2 until they can move one at a time, each with a test that its existing
+// state upgrades.
+type frameworkProvider struct {
+	sdkProvider *schema.Provider
+}
+
+// newFrameworkProvider returns the framework provider muxed with sdkProvider
+func newFrameworkProvider(sdkProvider *schema.Provider) fwprovider.Provider {
+	return &frameworkProvider{sdkProvider: sdkProvider}
+}
+
+func (p *frameworkProvider) Metadata(ctx context.Context, req fwprovider.MetadataRequest, resp *fwprovider.MetadataResponse) {
+	resp.TypeName = "freshservice"
+	resp.Version = Version
+}
+
+// Schema serves the provider schema of the SDKv2 provider, as muxed servers must agree on it
+func (p *frameworkProvider) Schema(ctx context.Context, req fwprovider.SchemaRequest, resp *fwprovider.SchemaResponse) {
+	attributes, blocks := frameworkProviderSchema(p.sdkProvider.Schema)
+	resp.Schema = fwschema.Schema{Attributes: attributes, Blocks: blocks}
+}
+
+// Configure shares the configuration of the SDKv2 provider. The mux server configures its
+// servers one at a time in order, and ProviderServer puts the SDKv2 provider first.
+func (p *frameworkProvider) Configure(ctx context.Context, req fwprovider.ConfigureRequest, resp *fwprovider.ConfigureResponse) {
+	config, ok := p.sdkProvider.Meta().(*Config)
+	if !ok {
+		resp.Diagnostics.AddError("Provider is not configured", "The SDKv2 provider must be configured before the framework provider muxed with it.")
+		return
+	}
+
+	resp.ResourceData = config
+	resp.DataSourceData = config
+}
+
+// ValidateConfig allows a single defaults block when the configuration is validated. The
+// muxed schemas can't say so: the framework serves no MaxItems for blocks.
+func (p *frameworkProvider) ValidateConfig(ctx context.Context, req fwprovider.ValidateConfigRequest, resp *fwprovider.ValidateConfigResponse) {
+	var defaults types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("defaults"), &defaults)...)
+	if resp.Diagnostics.HasError() || defaults.IsNull() || defaults.IsUnknown() {
+		return
+	}
+
+	if n := len(defaults.Elements()); n > 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("defaults"), "Too many defaults blocks", fmt.Sprintf("Only one defaults block is allowed, got %d.", n))
+	}
+}
+
+func (p *frameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		newAssetTypeResource,
+	}
+}
+
+func (p *frameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+// frameworkProviderSchema converts the SDKv2 provider schema. Lists of resources become
+// list blocks, as in the schema the SDKv2 provider serves.
+func frameworkProviderSchema(sdkSchema map[string]*schema.Schema) (map[string]fwschema.Attribute, map[string]fwschema.Block) {
+	attributes := make(map[string]fwschema.Attribute)
+	blocks := make(map[string]fwschema.Block)
+
+	for name, s := range sdkSchema {
+		if elem, ok := s.Elem.(*schema.Resource); ok {
+			nestedAttributes, nestedBlocks := frameworkProviderSchema(elem.Schema)
+			blocks[name] = fwschema.ListNestedBlock{
+				Description:  s.Description,
+				NestedObject: fwschema.NestedBlockObject{Attributes: nestedAttributes, Blocks: nestedBlocks},
+			}
+			continue
+		}
+
+		switch s.Type {
+		case schema.TypeString:
+			attributes[name] = fwschema.StringAttribute{Required: s.Required, Optional: s.Optional, Sensitive: s.Sensitive, Description: s.Description}
+		case schema.TypeInt:
+			attributes[name] = fwschema.Int64Attribute{Required: s.Required, Optional: s.Optional, Sensitive: s.Sensitive, Description: s.Description}
+		case schema.TypeBool:
+			attributes[name] = fwschema.BoolAttribute{Required: s.Required, Optional: s.Optional, Sensitive: s.Sensitive, Description: s.Description}
+		case schema.TypeSet:
+			attributes[name] = fwschema.SetAttribute{ElementType: frameworkElementType(name, s), Required: s.Required, Optional: s.Optional, Sensitive: s.Sensitive, Description: s.Description}
+		case schema.TypeMap:
+			attributes[name] = fwschema.MapAttribute{ElementType: frameworkElementType(name, s), Required: s.Required, Optional: s.Optional, Sensitive: s.Sensitive, Description: s.Description}
+		default:
+			panic(fmt.Sprintf("provider attribute %s has unsupported type %s", name, s.Type))
+		}
+	}
+
+	return attributes, blocks
+}
+
+// frameworkElementType returns the element type of a set or map of primitives
+func frameworkElementType(name string, s *schema.Schema) attr.Type {
+	elem, ok := s.Elem.(*schema.Schema)
+	if !ok {
+		panic(fmt.Sprintf("provider attribute %s has unsupported elements", name))
+	}
+
+	switch elem.Type {
+	case schema.TypeString:
+		return types.StringType
+	case schema.TypeInt:
+		return types.Int64Type
+	case schema.TypeBool:
+		return types.BoolType
+	}
+	panic(fmt.Sprintf("provider attribute %s has unsupported element type %s", name, elem.Type))
+}
+
+// configureFrameworkResource returns the provider configuration passed to a framework
+// resource or data source, or nil before the provider is configured
+func configureFrameworkResource(providerData any, diags *fwdiag.Diagnostics) *Config {
+	if providerData == nil {
+		return nil
+	}
+
+	config, ok := providerData.(*Config)
+	if !ok {
+		diags.AddError("Unexpected provider data", fmt.Sprintf("Expected *Config, got %T.", providerData))
+		return nil
+	}
+	return config
+}
+
+// frameworkDiagnostics converts SDKv2 diagnostics, so framework resources report errors
+// with the same helpers as the others
+func frameworkDiagnostics(diags diag.Diagnostics) fwdiag.Diagnostics {
+	var converted fwdiag.Diagnostics
+	for _, d := range diags {
+		attrPath, ok := frameworkPath(d.AttributePath)
+
+		switch {
+		case d.Severity == diag.Error && ok:
+			converted.AddAttributeError(attrPath, d.Summary, d.Detail)
+		case d.Severity == diag.Error:
+			converted.AddError(d.Summary, d.Detail)
+		case ok:
+			converted.AddAttributeWarning(attrPath, d.Summary, d.Detail)
+		default:
+			converted.AddWarning(d.Summary, d.Detail)
+		}
+	}
+	return converted
+}
+
+// frameworkPath converts a cty path of attribute names, map keys and list indexes.
+// It returns false for empty paths.
+func frameworkPath(ctyPath cty.Path) (path.Path, bool) {
+	if len(ctyPath) == 0 {
+		return path.Empty(), false
+	}
+
+	first, ok := ctyPath[0].(cty.GetAttrStep)
+	if !ok {
+		return path.Empty(), false
+	}
+
+	p := path.Root(first.Name)
+	for _, step := range ctyPath[1:] {
+		switch s := step.(type) {
+		case cty.GetAttrStep:
+			p = p.AtName(s.Name)
+		case cty.IndexStep:
+			if s.Key.Type() == cty.String {
+				p = p.AtMapKey(s.Key.AsString())
+			} else {
+				index, _ := s.Key.AsBigFloat().Int64()
+				p = p.AtListIndex(int(index))
+			}
+		}
+	}
+	return p, true
+}
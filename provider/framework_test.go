@@ -0,0 +1,508 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// protoUnknownValue stands for unknown values in flatmap attributes of planned states
+const protoUnknownValue = "(known after apply)"
+
+// protoChange is a planned change of a resource served over the protocol
+type protoChange struct {
+	prior, config, planned tftypes.Value
+}
+
+// diff returns the changed attributes of the plan, or nil when there are none
+func (c protoChange) diff() *terraform.InstanceDiff {
+	prior, planned := flatmapAttributes(c.prior), flatmapAttributes(c.planned)
+
+	diff := &terraform.InstanceDiff{Attributes: make(map[string]*terraform.ResourceAttrDiff)}
+	for key, value := range planned {
+		if old, ok := prior[key]; !ok || old != value {
+			diff.Attributes[key] = &terraform.ResourceAttrDiff{Old: old, New: value, NewComputed: value == protoUnknownValue}
+		}
+	}
+	for key, old := range prior {
+		if _, ok := planned[key]; !ok {
+			diff.Attributes[key] = &terraform.ResourceAttrDiff{Old: old, NewRemoved: true}
+		}
+	}
+
+	if c.planned.IsNull() {
+		diff.Destroy = true
+	} else if len(diff.Attributes) == 0 {
+		return nil
+	}
+	return diff
+}
+
+// resourceType returns the type of a resource served over the protocol
+func (h *testHarness) resourceType(name string) tftypes.Object {
+	h.t.Helper()
+
+	s, ok := h.schemas[name]
+	if !ok {
+		h.t.Fatalf("unknown resource %s", name)
+	}
+	return s.ValueType().(tftypes.Object)
+}
+
+// protoState converts a state to a value of the resource type
+func (h *testHarness) protoState(name string, state *terraform.InstanceState) tftypes.Value {
+	h.t.Helper()
+
+	typ := h.resourceType(name)
+	if state == nil {
+		return tftypes.NewValue(typ, nil)
+	}
+
+	values := make(map[string]interface{}, len(state.Attributes))
+	for key, value := range state.Attributes {
+		values[key] = value
+	}
+	values["id"] = state.ID
+	return protoValue(h.t, typ, values)
+}
+
+// protoPlan validates the configuration and plans the change of the resource, like
+// Terraform does with the proposed new state of the configuration and the state
+func (h *testHarness) protoPlan(name string, state *terraform.InstanceState, raw map[string]interface{}) (protoChange, error) {
+	h.t.Helper()
+
+	typ := h.resourceType(name)
+	change := protoChange{prior: h.protoState(name, state), config: protoValue(h.t, typ, raw)}
+
+	validate, err := h.server.ValidateResourceTypeConfig(context.Background(), &tfprotov5.ValidateResourceTypeConfigRequest{
+		TypeName: name,
+		Config:   dynamicValue(h.t, typ, change.config),
+	})
+	if err != nil {
+		return change, err
+	}
+	if err := protoDiagsError(validate.Diagnostics); err != nil {
+		return change, err
+	}
+
+	return change, h.planChange(name, &change, proposedNewState(h.schemas[name], change.prior, change.config))
+}
+
+// planChange plans change with the proposed new state, setting the planned state
+func (h *testHarness) planChange(name string, change *protoChange, proposed tftypes.Value) error {
+	h.t.Helper()
+
+	typ := h.resourceType(name)
+	resp, err := h.server.PlanResourceChange(context.Background(), &tfprotov5.PlanResourceChangeRequest{
+		TypeName:         name,
+		PriorState:       dynamicValue(h.t, typ, change.prior),
+		ProposedNewState: dynamicValue(h.t, typ, proposed),
+		Config:           dynamicValue(h.t, typ, change.config),
+	})
+	if err != nil {
+		return err
+	}
+	if err := protoDiagsError(resp.Diagnostics); err != nil {
+		return err
+	}
+	if len(resp.RequiresReplace) > 0 && !change.prior.IsNull() {
+		h.t.Fatalf("planning %s: replacing resources is not supported by the test harness", name)
+	}
+
+	change.planned = unmarshalDynamicValue(h.t, typ, resp.PlannedState)
+	return nil
+}
+
+// protoApply plans and applies the configuration, returning the new state
+func (h *testHarness) protoApply(name string, state *terraform.InstanceState, raw map[string]interface{}) (*terraform.InstanceState, error) {
+	h.t.Helper()
+
+	change, err := h.protoPlan(name, state, raw)
+	if err != nil {
+		return state, err
+	}
+	if change.diff() == nil {
+		return state, nil
+	}
+
+	newState, err := h.applyChange(name, change)
+	if err != nil {
+		return state, err
+	}
+	return instanceState(newState), nil
+}
+
+// applyChange applies a planned change, returning the new state
+func (h *testHarness) applyChange(name string, change protoChange) (tftypes.Value, error) {
+	h.t.Helper()
+
+	typ := h.resourceType(name)
+	resp, err := h.server.ApplyResourceChange(context.Background(), &tfprotov5.ApplyResourceChangeRequest{
+		TypeName:     name,
+		PriorState:   dynamicValue(h.t, typ, change.prior),
+		PlannedState: dynamicValue(h.t, typ, change.planned),
+		Config:       dynamicValue(h.t, typ, change.config),
+	})
+	if err != nil {
+		return change.prior, err
+	}
+	if err := protoDiagsError(resp.Diagnostics); err != nil {
+		return change.prior, err
+	}
+	return unmarshalDynamicValue(h.t, typ, resp.NewState), nil
+}
+
+// protoRefresh reads the resource, returning nil when it no longer exists
+func (h *testHarness) protoRefresh(name string, state *terraform.InstanceState) *terraform.InstanceState {
+	h.t.Helper()
+
+	typ := h.resourceType(name)
+	resp, err := h.server.ReadResource(context.Background(), &tfprotov5.ReadResourceRequest{
+		TypeName:     name,
+		CurrentState: dynamicValue(h.t, typ, h.protoState(name, state)),
+	})
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	requireNoProtoDiags(h.t, resp.Diagnostics)
+
+	return instanceState(unmarshalDynamicValue(h.t, typ, resp.NewState))
+}
+
+// protoImport imports a resource by ID, without reading it
+func (h *testHarness) protoImport(name, id string) *terraform.InstanceState {
+	h.t.Helper()
+
+	resp, err := h.server.ImportResourceState(context.Background(), &tfprotov5.ImportResourceStateRequest{TypeName: name, ID: id})
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	requireNoProtoDiags(h.t, resp.Diagnostics)
+	if len(resp.ImportedResources) != 1 {
+		h.t.Fatalf("importing %s %q: expected 1 state, got %d", name, id, len(resp.ImportedResources))
+	}
+
+	return instanceState(unmarshalDynamicValue(h.t, h.resourceType(name), resp.ImportedResources[0].State))
+}
+
+// protoDestroy plans and applies the deletion of the resource
+func (h *testHarness) protoDestroy(name string, state *terraform.InstanceState) {
+	h.t.Helper()
+
+	typ := h.resourceType(name)
+	change := protoChange{prior: h.protoState(name, state), config: tftypes.NewValue(typ, nil)}
+	if err := h.planChange(name, &change, tftypes.NewValue(typ, nil)); err != nil {
+		h.t.Fatalf("planning the deletion of %s: %s", name, err)
+	}
+	if _, err := h.applyChange(name, change); err != nil {
+		h.t.Fatalf("deleting %s: %s", name, err)
+	}
+}
+
+// proposedNewState merges the configuration with the prior state like Terraform: computed
+// attributes the configuration leaves null keep their prior value
+func proposedNewState(s *tfprotov5.Schema, prior, config tftypes.Value) tftypes.Value {
+	if config.IsNull() || prior.IsNull() {
+		return config
+	}
+
+	var priorAttrs, configAttrs map[string]tftypes.Value
+	_ = prior.As(&priorAttrs)
+	_ = config.As(&configAttrs)
+
+	proposed := make(map[string]tftypes.Value, len(configAttrs))
+	for name, value := range configAttrs {
+		proposed[name] = value
+	}
+	for _, attr := range s.Block.Attributes {
+		if attr.Computed && configAttrs[attr.Name].IsNull() {
+			proposed[attr.Name] = priorAttrs[attr.Name]
+		}
+	}
+	return tftypes.NewValue(config.Type(), proposed)
+}
+
+// protoValue converts configuration or flatmap state values to a value of an object type.
+// Attributes without a value are null. Only primitive attributes and lists of blocks can
+// have values.
+func protoValue(t *testing.T, typ tftypes.Object, values map[string]interface{}) tftypes.Value {
+	t.Helper()
+
+	attrs := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+	for name, attrType := range typ.AttributeTypes {
+		value, ok := values[name]
+		if !ok {
+			attrs[name] = tftypes.NewValue(attrType, nil)
+			continue
+		}
+
+		if list, ok := attrType.(tftypes.List); ok {
+			elemType, ok := list.ElementType.(tftypes.Object)
+			if !ok {
+				t.Fatalf("attribute %s of type %s is not supported by the test harness", name, attrType)
+			}
+			var elems []tftypes.Value
+			for _, elem := range value.([]interface{}) {
+				elems = append(elems, protoValue(t, elemType, elem.(map[string]interface{})))
+			}
+			attrs[name] = tftypes.NewValue(attrType, elems)
+			continue
+		}
+
+		s := fmt.Sprint(value)
+		switch {
+		case attrType.Is(tftypes.String):
+			attrs[name] = tftypes.NewValue(attrType, s)
+		case attrType.Is(tftypes.Number):
+			n, _, err := big.ParseFloat(s, 10, 512, big.ToNearestEven)
+			if err != nil {
+				t.Fatalf("attribute %s: %s", name, err)
+			}
+			attrs[name] = tftypes.NewValue(attrType, n)
+		case attrType.Is(tftypes.Bool):
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				t.Fatalf("attribute %s: %s", name, err)
+			}
+			attrs[name] = tftypes.NewValue(attrType, b)
+		default:
+			t.Fatalf("attribute %s of type %s is not supported by the test harness", name, attrType)
+		}
+	}
+	return tftypes.NewValue(typ, attrs)
+}
+
+// flatmapAttributes converts an object of primitive attributes to flatmap attributes,
+// leaving out null ones
+func flatmapAttributes(value tftypes.Value) map[string]string {
+	flat := make(map[string]string)
+	if value.IsNull() {
+		return flat
+	}
+
+	var attrs map[string]tftypes.Value
+	_ = value.As(&attrs)
+	for name, attr := range attrs {
+		switch {
+		case attr.IsNull():
+			continue
+		case !attr.IsKnown():
+			flat[name] = protoUnknownValue
+		case attr.Type().Is(tftypes.String):
+			var s string
+			_ = attr.As(&s)
+			flat[name] = s
+		case attr.Type().Is(tftypes.Number):
+			var n big.Float
+			_ = attr.As(&n)
+			flat[name] = n.Text('f', -1)
+		case attr.Type().Is(tftypes.Bool):
+			var b bool
+			_ = attr.As(&b)
+			flat[name] = strconv.FormatBool(b)
+		}
+	}
+	return flat
+}
+
+// instanceState converts a state value, returning nil for a null one
+func instanceState(value tftypes.Value) *terraform.InstanceState {
+	if value.IsNull() {
+		return nil
+	}
+	attributes := flatmapAttributes(value)
+	return &terraform.InstanceState{ID: attributes["id"], Attributes: attributes}
+}
+
+// dynamicValue encodes a value for a protocol request
+func dynamicValue(t *testing.T, typ tftypes.Type, value tftypes.Value) *tfprotov5.DynamicValue {
+	t.Helper()
+
+	dv, err := tfprotov5.NewDynamicValue(typ, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &dv
+}
+
+// unmarshalDynamicValue decodes a value of a protocol response, which is null when missing
+func unmarshalDynamicValue(t *testing.T, typ tftypes.Type, dv *tfprotov5.DynamicValue) tftypes.Value {
+	t.Helper()
+
+	if dv == nil {
+		return tftypes.NewValue(typ, nil)
+	}
+	value, err := dv.Unmarshal(typ)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
+
+// requireNoProtoDiags fails the test when protocol diagnostics contain an error
+func requireNoProtoDiags(t *testing.T, diags []*tfprotov5.Diagnostic) {
+	t.Helper()
+
+	if err := protoDiagsError(diags); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// protoDiagsError converts error protocol diagnostics into an error, formatted like diagsError
+func protoDiagsError(diags []*tfprotov5.Diagnostic) error {
+	var errs []error
+	for _, d := range diags {
+		if d.Severity != tfprotov5.DiagnosticSeverityError {
+			continue
+		}
+
+		msg := d.Summary
+		if d.Detail != "" {
+			msg += ": " + d.Detail
+		}
+		if d.Attribute != nil && len(d.Attribute.Steps()) > 0 {
+			msg = attributePathString(d.Attribute) + ": " + msg
+		}
+		errs = append(errs, errors.New(msg))
+	}
+	return errors.Join(errs...)
+}
+
+// attributePathString formats an attribute path like pathString
+func attributePathString(path *tftypes.AttributePath) string {
+	var b strings.Builder
+	for _, step := range path.Steps() {
+		switch s := step.(type) {
+		case tftypes.AttributeName:
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(string(s))
+		case tftypes.ElementKeyString:
+			fmt.Fprintf(&b, "[%q]", string(s))
+		case tftypes.ElementKeyInt:
+			fmt.Fprintf(&b, "[%d]", int64(s))
+		}
+	}
+	return b.String()
+}
+
+func TestFrameworkProvider_sharesConfig(t *testing.T) {
+	h := newTestHarness(t)
+
+	// Framework resources use the configuration of the SDKv2 provider
+	h.config.ReadOnly = true
+
+	_, err := h.tryApply("freshservice_asset_type", nil, map[string]interface{}{"name": "Printer"})
+	if err == nil || !strings.Contains(err.Error(), "Cannot create freshservice_asset_type: the provider is read only") {
+		t.Fatalf("expected the create to be refused, got %v", err)
+	}
+	if n := h.fake.CountRequests("POST "); n != 0 {
+		t.Errorf("expected no write requests, got %d", n)
+	}
+}
+
+func TestResourceAssetType_sdkState(t *testing.T) {
+	h := newTestHarness(t)
+
+	// State written by the SDKv2 resource, with an empty description and no parent
+	assetType := h.apply("freshservice_asset_type", nil, map[string]interface{}{"name": "Printer"})
+	rawState := fmt.Sprintf(`{
+		"id": %q,
+		"name": "Printer",
+		"description": "",
+		"parent_asset_type_id": null,
+		"visible": true,
+		"created_at": %q,
+		"updated_at": %q
+	}`, assetType.ID, assetType.Attributes["created_at"], assetType.Attributes["updated_at"])
+
+	typ := h.resourceType("freshservice_asset_type")
+	resp, err := h.server.UpgradeResourceState(context.Background(), &tfprotov5.UpgradeResourceStateRequest{
+		TypeName: "freshservice_asset_type",
+		Version:  0,
+		RawState: &tfprotov5.RawState{JSON: []byte(rawState)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireNoProtoDiags(t, resp.Diagnostics)
+
+	state := instanceState(unmarshalDynamicValue(t, typ, resp.UpgradedState))
+	requireAttributes(t, state, map[string]string{
+		"id":          assetType.ID,
+		"name":        "Printer",
+		"description": "",
+		"visible":     "true",
+	})
+
+	// Refreshing and planning the unchanged configuration leave the state as it is
+	state = h.refresh("freshservice_asset_type", state)
+	h.requireNoPlan("freshservice_asset_type", state, map[string]interface{}{"name": "Printer"})
+
+	// So does the configuration of an asset type with a parent
+	config := map[string]interface{}{"name": "Printer", "description": "Office printers", "parent_asset_type_id": testHardwareTypeID}
+	state = h.apply("freshservice_asset_type", state, config)
+	rawState = fmt.Sprintf(`{
+		"id": %q,
+		"name": "Printer",
+		"description": "Office printers",
+		"parent_asset_type_id": %d,
+		"visible": true,
+		"created_at": %q,
+		"updated_at": %q
+	}`, state.ID, testHardwareTypeID, state.Attributes["created_at"], state.Attributes["updated_at"])
+
+	resp, err = h.server.UpgradeResourceState(context.Background(), &tfprotov5.UpgradeResourceStateRequest{
+		TypeName: "freshservice_asset_type",
+		Version:  0,
+		RawState: &tfprotov5.RawState{JSON: []byte(rawState)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireNoProtoDiags(t, resp.Diagnostics)
+
+	state = h.refresh("freshservice_asset_type", instanceState(unmarshalDynamicValue(t, typ, resp.UpgradedState)))
+	h.requireNoPlan("freshservice_asset_type", state, config)
+}
+
+func TestFrameworkProvider_validatesDefaults(t *testing.T) {
+	newServer, err := ProviderServer(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := newServer()
+
+	schemas, err := server.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	providerType := schemas.Provider.ValueType().(tftypes.Object)
+
+	// Several defaults blocks fail validation, before the provider is configured
+	resp, err := server.PrepareProviderConfig(context.Background(), &tfprotov5.PrepareProviderConfigRequest{
+		Config: dynamicValue(t, providerType, protoValue(t, providerType, map[string]interface{}{
+			"api_key": testAPIKey,
+			"domain":  "acme",
+			"defaults": []interface{}{
+				map[string]interface{}{"managed_by": "Managed by Terraform"},
+				map[string]interface{}{"managed_by": "Managed by OpenTofu"},
+			},
+		})),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := protoDiagsError(resp.Diagnostics); err == nil || !strings.Contains(err.Error(), "Only one defaults block is allowed, got 2") {
+		t.Fatalf("expected a defaults error, got %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// httpLogSubsystem is the tflog subsystem for API requests and responses. Its level
+// can be set on its own with TF_LOG_PROVIDER_FRESHSERVICE_HTTP.
+const httpLogSubsystem = "http"
+
+// httpLogger writes the log messages of the Freshservice client to the http subsystem,
+// with the API key masked wherever it might appear
+type httpLogger struct {
+	apiKey string
+}
+
+func (l httpLogger) Debug(ctx context.Context, msg string, fields map[string]interface{}) {
+	tflog.SubsystemDebug(l.context(ctx), httpLogSubsystem, msg, fields)
+}
+
+func (l httpLogger) Warn(ctx context.Context, msg string, fields map[string]interface{}) {
+	tflog.SubsystemWarn(l.context(ctx), httpLogSubsystem, msg, fields)
+}
+
+// context returns a context logging to the http subsystem with the masks applied
+func (l httpLogger) context(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, httpLogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_FRESHSERVICE_HTTP"))
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, httpLogSubsystem, "authorization")
+	if l.apiKey != "" {
+		basicAuth := base64.StdEncoding.EncodeToString([]byte(l.apiKey + ":X"))
+		ctx = tflog.SubsystemMaskLogStrings(ctx, httpLogSubsystem, l.apiKey, basicAuth)
+	}
+	return ctx
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestHTTPLogger_masksAPIKey(t *testing.T) {
+	const apiKey = "test-api-key"
+	basicAuth := base64.StdEncoding.EncodeToString([]byte(apiKey + ":X"))
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	logger := httpLogger{apiKey: apiKey}
+	logger.Debug(ctx, "Sending API request", map[string]interface{}{
+		"http_url":      "https://acme.freshservice.com/api/v2/assets?key=" + apiKey,
+		"authorization": "Basic " + basicAuth,
+	})
+	logger.Warn(ctx, "API request failed, retrying", map[string]interface{}{"error": "invalid key " + apiKey})
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 log entries, got %v", entries)
+	}
+	for _, entry := range entries {
+		if entry["@module"] != "provider."+httpLogSubsystem {
+			t.Errorf("expected %q to be logged by the http subsystem, got %v", entry["@message"], entry["@module"])
+		}
+	}
+
+	logged := output.String()
+	for _, secret := range []string{apiKey, basicAuth} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("expected %q to be masked in logs:\n%s", secret, logged)
+		}
+	}
+}
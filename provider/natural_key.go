@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lcp-llp/terraform-provider-freshservice/freshservice"
+)
+
+// Natural keys of every asset, besides the type fields of the resources with dedicated attributes
+const (
+	naturalKeyName     = "name"
+	naturalKeyAssetTag = "asset_tag"
+)
+
+// findAssetsByNaturalKey returns the active assets whose natural key holds value. key is
+// name, asset_tag or a type field of assetTypeID, without the asset type ID suffix. An
+// assetTypeID of 0 matches assets of any type.
+func findAssetsByNaturalKey(ctx context.Context, config *Config, assetTypeID int, key, value string) ([]freshservice.Asset, error) {
+	var opts *freshservice.AssetListOptions
+	var match func(asset freshservice.Asset) bool
+
+	switch key {
+	case naturalKeyName:
+		opts = &freshservice.AssetListOptions{Search: buildSearchQuery(naturalKeyName, value)}
+		match = func(asset freshservice.Asset) bool { return asset.Name == value }
+	case naturalKeyAssetTag:
+		opts = &freshservice.AssetListOptions{Search: buildSearchQuery(naturalKeyAssetTag, value)}
+		match = func(asset freshservice.Asset) bool { return asset.AssetTag == value }
+	default:
+		// Type fields can't be searched, so the assets of the type are matched here. List
+		// asks for their type fields, which the API leaves out otherwise.
+		name := fmt.Sprintf("%s_%d", key, assetTypeID)
+		opts = &freshservice.AssetListOptions{Filter: fmt.Sprintf(`"asset_type_id:%d"`, assetTypeID)}
+		match = func(asset freshservice.Asset) bool {
+			return freshservice.FormatFieldValue(asset.TypeFields[name]) == value
+		}
+	}
+
+	var matches []freshservice.Asset
+	for asset, err := range config.Assets.List(ctx, opts) {
+		if err != nil {
+			return nil, err
+		}
+		if (assetTypeID == 0 || asset.AssetTypeID == assetTypeID) && match(asset) {
+			matches = append(matches, asset)
+		}
+	}
+	return matches, nil
+}
+
+// assetDisplayIDs lists the display IDs of assets for error messages
+func assetDisplayIDs(assets []freshservice.Asset) string {
+	displayIDs := make([]string, len(assets))
+	for i, asset := range assets {
+		displayIDs[i] = strconv.Itoa(asset.DisplayID)
+	}
+	return strings.Join(displayIDs, ", ")
+}
+
+// importAssetState returns the importer of an asset resource. Besides a display ID, it
+// accepts <key>=<value> with name, asset_tag or one of typeFieldKeys, type fields of
+// assetTypeID, and imports the single asset matching it. The key may be prefixed with
+// <asset_type_id>/ to match assets of another asset type than assetTypeID, which is
+// the default asset type of the resource. An assetTypeID of 0 matches assets of any type.
+func importAssetState(assetTypeID int, typeFieldKeys ...string) schema.StateContextFunc {
+	keys := append([]string{naturalKeyName, naturalKeyAssetTag}, typeFieldKeys...)
+
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		config := meta.(*Config)
+
+		// Display IDs are used as is
+		if _, err := strconv.Atoi(d.Id()); err == nil {
+			return []*schema.ResourceData{d}, nil
+		}
+
+		typeID := assetTypeID
+		keyValue := d.Id()
+		if prefix, rest, ok := strings.Cut(keyValue, "/"); ok && !strings.Contains(prefix, "=") {
+			id, err := strconv.Atoi(prefix)
+			if err != nil || id <= 0 {
+				return nil, fmt.Errorf("invalid asset type ID %q in import ID %q", prefix, d.Id())
+			}
+			typeID, keyValue = id, rest
+		}
+
+		key, value, ok := strings.Cut(keyValue, "=")
+		if !ok || value == "" || !slices.Contains(keys, key) {
+			return nil, fmt.Errorf("invalid import ID %q, expected a display ID or [<asset_type_id>/]<key>=<value> with key one of %s", d.Id(), strings.Join(keys, ", "))
+		}
+
+		assets, err := findAssetsByNaturalKey(ctx, config, typeID, key, value)
+		if err != nil {
+			return nil, err
+		}
+		switch len(assets) {
+		case 0:
+			return nil, fmt.Errorf("no asset found with %s %q", key, value)
+		case 1:
+			d.SetId(strconv.Itoa(assets[0].DisplayID))
+			return []*schema.ResourceData{d}, nil
+		}
+		return nil, fmt.Errorf("%d assets found with %s %q (display IDs %s), import one of them by display ID", len(assets), key, value, assetDisplayIDs(assets))
+	}
+}
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestImportAssetState_naturalKey(t *testing.T) {
+	h := newTestHarness(t)
+
+	aws := h.apply("freshservice_aws_account", nil, map[string]interface{}{
+		"account_name": "Production",
+		"account_id":   "123456789012",
+	})
+	azure := h.apply("freshservice_azure_subscription", nil, map[string]interface{}{
+		"subscription_name": "Production",
+		"subscription_id":   "00000000-0000-0000-0000-000000000001",
+		"tenant_id":         "00000000-0000-0000-0000-0000000000aa",
+	})
+	gcp := h.apply("freshservice_gcp_project", nil, map[string]interface{}{
+		"project_name": "Production",
+		"project_id":   "prod-1234",
+	})
+	laptop := h.apply("freshservice_asset", nil, map[string]interface{}{
+		"name":          "Developer Laptop",
+		"asset_type_id": testHardwareTypeID,
+		"asset_tag":     "LAP-0001",
+	})
+
+	for _, tc := range []struct {
+		name string
+		id   string
+		want string
+	}{
+		{"freshservice_aws_account", "account_id=123456789012", aws.ID},
+		{"freshservice_aws_account", "name=Production", aws.ID},
+		{"freshservice_aws_account", aws.ID, aws.ID},
+		{"freshservice_azure_subscription", "subscription_id=00000000-0000-0000-0000-000000000001", azure.ID},
+		{"freshservice_gcp_project", "project_id=prod-1234", gcp.ID},
+		{"freshservice_gcp_project", "asset_tag=" + gcp.Attributes["asset_tag"], gcp.ID},
+		{"freshservice_asset", "asset_tag=LAP-0001", laptop.ID},
+		{"freshservice_cloud_asset", "name=Developer Laptop", laptop.ID},
+	} {
+		if imported := h.importState(tc.name, tc.id); imported.ID != tc.want {
+			t.Errorf("importing %s %q: expected asset %s, got %s", tc.name, tc.id, tc.want, imported.ID)
+		}
+	}
+}
+
+func TestImportAssetState_errors(t *testing.T) {
+	h := newTestHarness(t)
+
+	config := map[string]interface{}{
+		"account_name": "Sandbox",
+		"account_id":   "210987654321",
+	}
+	first := h.apply("freshservice_aws_account", nil, config)
+	second := h.apply("freshservice_aws_account", nil, config)
+
+	for id, want := range map[string]string{
+		"account_id=000000000000": `no asset found with account_id "000000000000"`,
+		"account_id=210987654321": `2 assets found with account_id "210987654321" (display IDs ` + first.ID + ", " + second.ID + ")",
+		"project_id=sandbox-1234": "expected a display ID or [<asset_type_id>/]<key>=<value> with key one of name, asset_tag, account_id",
+		"aws/account_id=1":        `invalid asset type ID "aws"`,
+		"Sandbox":                 "invalid import ID",
+	} {
+		_, err := h.provider.ImportState(context.Background(), &terraform.InstanceInfo{Type: "freshservice_aws_account"}, id)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("importing %q: expected an error containing %q, got %v", id, want, err)
+		}
+	}
+}
+
+func TestImportAssetState_assetType(t *testing.T) {
+	h := newTestHarness(t)
+
+	// A second AWS account asset type, as some tenants have
+	assetType := h.apply("freshservice_asset_type", nil, map[string]interface{}{"name": "AWS GovCloud Account"})
+	h.apply("freshservice_asset_type_field", nil, map[string]interface{}{
+		"asset_type_id": assetType.ID,
+		"label":         "Account ID",
+		"field_type":    "text",
+	})
+	account := h.apply("freshservice_aws_account", nil, map[string]interface{}{
+		"account_name":  "GovCloud",
+		"account_id":    "345678901234",
+		"asset_type_id": assetType.ID,
+	})
+
+	// Only assets of the default asset type match without an asset type in the ID
+	if _, err := h.provider.ImportState(context.Background(), &terraform.InstanceInfo{Type: "freshservice_aws_account"}, "account_id=345678901234"); err == nil || !strings.Contains(err.Error(), "no asset found") {
+		t.Errorf("expected no asset of the default asset type to match, got %v", err)
+	}
+
+	imported := h.importState("freshservice_aws_account", assetType.ID+"/account_id=345678901234")
+	if imported.ID != account.ID {
+		t.Fatalf("expected asset %s, got %s", account.ID, imported.ID)
+	}
+	requireAttributes(t, imported, map[string]string{"asset_type_id": assetType.ID, "account_id": "345678901234"})
+}
+
+// TestFindAssetsByNaturalKey_typeFields matches type fields of listed assets, which the
+// API only returns when they are asked for
+func TestFindAssetsByNaturalKey_typeFields(t *testing.T) {
+	h := newTestHarness(t)
+
+	aws := h.apply("freshservice_aws_account", nil, map[string]interface{}{
+		"account_name": "Production",
+		"account_id":   "123456789012",
+	})
+
+	assets, err := findAssetsByNaturalKey(context.Background(), h.config, defaultAWSAccountTypeID, "account_id", "123456789012")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(assets) != 1 || strconv.Itoa(assets[0].DisplayID) != aws.ID {
+		t.Fatalf("expected asset %s, got %+v", aws.ID, assets)
+	}
+
+	for _, r := range h.fake.Requests() {
+		if strings.HasPrefix(r, "GET /api/v2/assets?") && !strings.Contains(r, "include=type_fields") {
+			t.Errorf("assets listed without type fields: %s", r)
+		}
+	}
+}
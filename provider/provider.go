@@ -2,49 +2,270 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lcp-llp/terraform-provider-freshservice/freshservice"
 )
 
+// maxRetriesLimit is the largest max_retries accepted. Retries back off exponentially,
+// so more would only keep a failing apply waiting for hours.
+const maxRetriesLimit = 20
+
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"api_key": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "API key for Freshservice",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"api_key_file"},
+				Description:   "API key for Freshservice. Can also be set with the FRESHSERVICE_API_KEY environment variable",
+			},
+			"api_key_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"api_key"},
+				Description:   "Path of a file containing the API key for Freshservice. Can also be set with the FRESHSERVICE_API_KEY_FILE environment variable",
 			},
 			"domain": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc(DomainEnvVar, nil),
+				Description:  "Domain for Freshservice (e.g., 'yourdomain', 'yourdomain.freshservice.com' or 'yourdomain.freshservice.eu'). Can also be set with the FRESHSERVICE_DOMAIN environment variable. Custom domains are deprecated here, use base_url for them",
+				ValidateFunc: validateDomain,
+			},
+			"base_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Base URL of the Freshservice API, overriding the URL derived from domain (e.g., 'https://helpdesk.example.com/api/v2'). Must use https, except for localhost",
+				ValidateFunc: func(v interface{}, k string) ([]string, []error) {
+					if _, err := freshservice.NormalizeBaseURL(v.(string)); err != nil {
+						return nil, []error{err}
+					}
+					return nil, nil
+				},
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      freshservice.DefaultMaxRetries,
+				ValidateFunc: validation.IntBetween(0, maxRetriesLimit),
+				Description:  "Maximum number of times a rate limited or failed request is retried, at most 20 (default: 5)",
+			},
+			"max_retry_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(freshservice.DefaultMaxRetryWait / time.Second),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Maximum number of seconds to wait between two retries (default: 60)",
+			},
+			"requests_per_minute": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of requests per minute sent to the API by all resources and data sources together (default: 0, no client side limit)",
+			},
+			"default_workspace_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the workspace new assets are created in when they don't set workspace_id. Defaults to the default workspace of the account",
+			},
+			"request_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(freshservice.DefaultRequestTimeout / time.Second),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Maximum number of seconds a single request may take, including reading the response (default: 60). Each retry gets a new timeout",
+			},
+			"proxy_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+				Description:  "URL of the proxy requests are sent through. Defaults to the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables",
+			},
+			"ca_bundle_file": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Domain for Freshservice (e.g., 'yourdomain.freshservice.com')",
+				Optional:    true,
+				Description: "Path of a PEM file of certificate authorities to trust in addition to the system ones, e.g. for a TLS inspecting proxy",
+			},
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Skip TLS certificate verification. Only use this for testing",
+			},
+			"sensitive_type_fields": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of type fields (without the asset type ID suffix, e.g. 'serial_number') whose values are masked in debug logs",
+			},
+			"read_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Only send GET requests, so the provider can plan and refresh but never change anything in Freshservice (default: false). Creating, updating or deleting a resource fails",
+			},
+			"defaults": {
+				// The framework provider muxed with this one can't limit the number of blocks in
+				// its schema, and the schemas must be identical, so there is no MaxItems. The
+				// framework provider checks for a single block when the configuration is
+				// validated, and configureProvider checks again.
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Defaults applied to every asset managed by the provider. At most one block is allowed",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type_fields": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Type fields set on every asset whose asset type has them, keyed by field name without the asset type ID suffix (e.g. 'owner'). Values set by a resource take precedence",
+						},
+						"managed_by": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Marker appended to the description of every asset, e.g. 'Managed by Terraform'. It is left out of the description attribute of resources",
+						},
+					},
+				},
+			},
+			"page_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      freshservice.DefaultPageSize,
+				ValidateFunc: validation.IntBetween(1, freshservice.MaxPageSize),
+				Description:  "Number of items requested per page when listing or searching (default: 100, maximum: 100)",
 			},
 		},
-		ConfigureContextFunc: configureProvider,
 		ResourcesMap: map[string]*schema.Resource{
 			"freshservice_asset":              resourceAsset(),
-			"freshservice_asset_type":         resourceAssetType(),
+			"freshservice_asset_type_field":   resourceAssetTypeField(),
+			"freshservice_cloud_asset":        resourceCloudAsset(),
 			"freshservice_azure_subscription": resourceAzureSubscription(),
 			"freshservice_aws_account":        resourceAWSAccount(),
 			"freshservice_gcp_project":        resourceGCPProject(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"freshservice_asset":      dataSourceAsset(),
+			"freshservice_assets":     dataSourceAssets(),
 			"freshservice_asset_type": dataSourceAssetType(),
 		},
 	}
+
+	for name, r := range p.ResourcesMap {
+		guardReadOnly(name, r)
+	}
+
+	cloudAsset := p.ResourcesMap["freshservice_cloud_asset"]
+	cloudAsset.ValidateRawResourceConfigFuncs = append(cloudAsset.ValidateRawResourceConfigFuncs, validateTypeFieldKeys(p.Meta))
+
+	p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		// The Terraform version is only known once Terraform configures the provider
+		return configureProvider(ctx, d, p.UserAgent(providerName, Version))
+	}
+
+	return p
+}
+
+// ProviderServer returns the factory of the server of the provider over plugin protocol 5.
+// It muxes the SDKv2 provider with the resources and data sources moved to
+// terraform-plugin-framework, which share the configuration of the SDKv2 provider.
+func ProviderServer(ctx context.Context) (func() tfprotov5.ProviderServer, error) {
+	return newProviderServer(ctx, Provider())
+}
+
+// newProviderServer muxes sdkProvider with the framework provider sharing its configuration
+func newProviderServer(ctx context.Context, sdkProvider *schema.Provider) (func() tfprotov5.ProviderServer, error) {
+	// The SDKv2 provider comes first, so it is configured before the framework provider
+	muxServer, err := tf5muxserver.NewMuxServer(ctx,
+		sdkProvider.GRPCProvider,
+		providerserver.NewProtocol5(newFrameworkProvider(sdkProvider)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return muxServer.ProviderServer, nil
 }
 
-// configureProvider configures the provider with authentication
-func configureProvider(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	apiKey := d.Get("api_key").(string)
+// validateDomain validates domain, pointing URLs to base_url
+func validateDomain(v interface{}, k string) ([]string, []error) {
+	if _, err := freshservice.NormalizeDomain(v.(string)); err != nil {
+		if errors.Is(err, freshservice.ErrDomainIsURL) {
+			err = fmt.Errorf("%w; use base_url to set the full API URL", err)
+		}
+		return nil, []error{err}
+	}
+	return nil, nil
+}
+
+// configureProvider configures the provider with authentication and transport settings
+func configureProvider(ctx context.Context, d *schema.ResourceData, userAgent string) (interface{}, diag.Diagnostics) {
+	apiKey, err := resolveAPIKey(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
 	domain := d.Get("domain").(string)
+	baseURL := d.Get("base_url").(string)
+	if domain == "" && baseURL == "" {
+		return nil, diag.Errorf("no domain configured: set domain, base_url or the %s environment variable", DomainEnvVar)
+	}
 
-	config, err := NewConfig(apiKey, domain)
+	config, err := NewConfig(apiKey, domain, baseURL)
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
 
-	return config, nil
+	var diags diag.Diagnostics
+	if baseURL == "" && !freshservice.IsFreshserviceDomain(config.Domain) {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       "Custom domain in domain is deprecated",
+			Detail:        fmt.Sprintf("%q is not a Freshservice domain, so it is used as base_url %q. Set base_url instead, as domain will only accept Freshservice domains in a future version.", config.Domain, config.BaseURL),
+			AttributePath: cty.GetAttrPath("domain"),
+		})
+	}
+
+	config.HTTPClient, err = freshservice.NewHTTPClient(ctx, freshservice.TransportOptions{
+		RequestTimeout:     time.Duration(d.Get("request_timeout").(int)) * time.Second,
+		ProxyURL:           d.Get("proxy_url").(string),
+		CABundleFile:       d.Get("ca_bundle_file").(string),
+		InsecureSkipVerify: d.Get("insecure_skip_verify").(bool),
+		Logger:             config.Logger,
+	})
+	if err != nil {
+		return nil, diag.Errorf("invalid proxy_url or ca_bundle_file: %s", err)
+	}
+	config.UserAgent = userAgent
+
+	config.MaxRetries = d.Get("max_retries").(int)
+	config.MaxRetryWait = time.Duration(d.Get("max_retry_wait").(int)) * time.Second
+	config.PageSize = d.Get("page_size").(int)
+	config.SetRequestsPerMinute(d.Get("requests_per_minute").(int))
+	config.ReadOnly = d.Get("read_only").(bool)
+	config.DefaultWorkspaceID = d.Get("default_workspace_id").(int)
+	defaults := d.Get("defaults").([]interface{})
+	if len(defaults) > 1 {
+		return nil, diag.Errorf("only one defaults block is allowed, got %d", len(defaults))
+	}
+	config.Defaults = expandAssetDefaults(defaults)
+
+	config.SensitiveTypeFields = make(map[string]bool)
+	for _, name := range d.Get("sensitive_type_fields").(*schema.Set).List() {
+		config.SensitiveTypeFields[name.(string)] = true
+	}
+
+	return config, diags
 }
@@ -0,0 +1,607 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+// testHarness runs resources and data sources of a configured provider against a
+// fake Freshservice API, going through the same diff, apply, refresh and import
+// steps Terraform drives over the plugin protocol. Resources of the framework
+// provider are driven over the protocol, through the server muxing both providers.
+type testHarness struct {
+	t        *testing.T
+	fake     *fakeFreshservice
+	provider *schema.Provider
+	config   *Config
+
+	// server is the mux server of the provider and schemas the resource schemas it serves
+	server  tfprotov5.ProviderServer
+	schemas map[string]*tfprotov5.Schema
+}
+
+// newTestHarness configures the provider against a new fake Freshservice API
+func newTestHarness(t *testing.T) *testHarness {
+	t.Helper()
+
+	fake := newFakeFreshservice(t)
+	p := Provider()
+
+	newServer, err := newProviderServer(context.Background(), p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := newServer()
+
+	schemas, err := server.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireNoProtoDiags(t, schemas.Diagnostics)
+
+	providerType := schemas.Provider.ValueType().(tftypes.Object)
+	resp, err := server.ConfigureProvider(context.Background(), &tfprotov5.ConfigureProviderRequest{
+		Config: dynamicValue(t, providerType, protoValue(t, providerType, map[string]interface{}{
+			"api_key":  testAPIKey,
+			"base_url": fake.URL(),
+		})),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireNoProtoDiags(t, resp.Diagnostics)
+
+	config := p.Meta().(*Config)
+	// Keep retries from slowing the tests down
+	config.MaxRetryWait = time.Millisecond
+
+	return &testHarness{t: t, fake: fake, provider: p, config: config, server: server, schemas: schemas.ResourceSchemas}
+}
+
+// frameworkResource reports whether a resource is served by the framework provider
+func (h *testHarness) frameworkResource(name string) bool {
+	_, sdk := h.provider.ResourcesMap[name]
+	_, served := h.schemas[name]
+	return !sdk && served
+}
+
+// resource returns a resource of the provider
+func (h *testHarness) resource(name string) *schema.Resource {
+	h.t.Helper()
+
+	r, ok := h.provider.ResourcesMap[name]
+	if !ok {
+		h.t.Fatalf("unknown resource %s", name)
+	}
+	return r
+}
+
+// plan validates the configuration and returns the diff against the state,
+// or nil when there are no changes
+func (h *testHarness) plan(name string, state *terraform.InstanceState, raw map[string]interface{}) (*terraform.InstanceDiff, error) {
+	h.t.Helper()
+
+	if h.frameworkResource(name) {
+		change, err := h.protoPlan(name, state, raw)
+		if err != nil {
+			return nil, err
+		}
+		return change.diff(), nil
+	}
+
+	r := h.resource(name)
+	c := terraform.NewResourceConfigRaw(raw)
+	if diags := r.Validate(c); diags.HasError() {
+		return nil, diagsError(diags)
+	}
+
+	diff, err := r.Diff(context.Background(), state, c, h.config)
+	if err != nil {
+		return nil, err
+	}
+	if diff == nil || diff.Empty() {
+		return nil, nil
+	}
+	return diff, nil
+}
+
+// apply plans and applies the configuration, returning the new state
+func (h *testHarness) apply(name string, state *terraform.InstanceState, raw map[string]interface{}) *terraform.InstanceState {
+	h.t.Helper()
+
+	newState, err := h.tryApply(name, state, raw)
+	if err != nil {
+		h.t.Fatalf("applying %s: %s", name, err)
+	}
+	return newState
+}
+
+// tryApply is apply returning the error instead of failing the test
+func (h *testHarness) tryApply(name string, state *terraform.InstanceState, raw map[string]interface{}) (*terraform.InstanceState, error) {
+	h.t.Helper()
+
+	if h.frameworkResource(name) {
+		return h.protoApply(name, state, raw)
+	}
+
+	diff, err := h.plan(name, state, raw)
+	if err != nil {
+		return state, err
+	}
+	if diff == nil {
+		return state, nil
+	}
+
+	newState, diags := h.resource(name).Apply(context.Background(), state, diff, h.config)
+	if diags.HasError() {
+		return newState, diagsError(diags)
+	}
+	return newState, nil
+}
+
+// refresh reads the resource, returning nil when it no longer exists
+func (h *testHarness) refresh(name string, state *terraform.InstanceState) *terraform.InstanceState {
+	h.t.Helper()
+
+	if h.frameworkResource(name) {
+		return h.protoRefresh(name, state)
+	}
+
+	newState, diags := h.resource(name).RefreshWithoutUpgrade(context.Background(), state, h.config)
+	requireNoDiags(h.t, diags)
+	if newState == nil || newState.ID == "" {
+		return nil
+	}
+	return newState
+}
+
+// importState imports a resource by ID and refreshes it, like terraform import
+func (h *testHarness) importState(name, id string) *terraform.InstanceState {
+	h.t.Helper()
+
+	if h.frameworkResource(name) {
+		state := h.protoRefresh(name, h.protoImport(name, id))
+		if state == nil {
+			h.t.Fatalf("importing %s %q: resource not found", name, id)
+		}
+		return state
+	}
+
+	states, err := h.provider.ImportState(context.Background(), &terraform.InstanceInfo{Type: name}, id)
+	if err != nil {
+		h.t.Fatalf("importing %s %q: %s", name, id, err)
+	}
+	if len(states) != 1 {
+		h.t.Fatalf("importing %s %q: expected 1 state, got %d", name, id, len(states))
+	}
+
+	state := h.refresh(name, states[0])
+	if state == nil {
+		h.t.Fatalf("importing %s %q: resource not found", name, id)
+	}
+	return state
+}
+
+// destroy deletes the resource
+func (h *testHarness) destroy(name string, state *terraform.InstanceState) {
+	h.t.Helper()
+
+	if h.frameworkResource(name) {
+		h.protoDestroy(name, state)
+		return
+	}
+
+	_, diags := h.resource(name).Apply(context.Background(), state, &terraform.InstanceDiff{Destroy: true}, h.config)
+	requireNoDiags(h.t, diags)
+}
+
+// readData reads a data source with the given configuration
+func (h *testHarness) readData(name string, raw map[string]interface{}) (*terraform.InstanceState, error) {
+	h.t.Helper()
+
+	r, ok := h.provider.DataSourcesMap[name]
+	if !ok {
+		h.t.Fatalf("unknown data source %s", name)
+	}
+
+	c := terraform.NewResourceConfigRaw(raw)
+	if diags := r.Validate(c); diags.HasError() {
+		return nil, diagsError(diags)
+	}
+
+	diff, err := r.Diff(context.Background(), nil, c, h.config)
+	if err != nil {
+		return nil, err
+	}
+
+	state, diags := r.ReadDataApply(context.Background(), diff, h.config)
+	if diags.HasError() {
+		return nil, diagsError(diags)
+	}
+	return state, nil
+}
+
+// requireNoPlan fails the test when applying the configuration would change anything
+func (h *testHarness) requireNoPlan(name string, state *terraform.InstanceState, raw map[string]interface{}) {
+	h.t.Helper()
+
+	diff, err := h.plan(name, state, raw)
+	if err != nil {
+		h.t.Fatalf("planning %s: %s", name, err)
+	}
+	if diff != nil {
+		h.t.Fatalf("expected an empty plan for %s, got %#v", name, diff.Attributes)
+	}
+}
+
+// assetResourceFixture is a minimal configuration of an asset-backed resource
+type assetResourceFixture struct {
+	config map[string]interface{}
+	// key is the natural key attribute of the resource besides name and asset_tag, if any
+	key string
+	// otherKey is a valid value of key other than the one in config
+	otherKey string
+}
+
+// assetResourceFixtures holds a fixture of every asset-backed resource
+var assetResourceFixtures = map[string]assetResourceFixture{
+	"freshservice_asset": {
+		config: map[string]interface{}{
+			"name":          "Monitor",
+			"asset_type_id": testHardwareTypeID,
+		},
+	},
+	"freshservice_cloud_asset": {
+		config: map[string]interface{}{
+			"name":          "Build Server",
+			"asset_type_id": testHardwareTypeID,
+		},
+	},
+	"freshservice_aws_account": {
+		config: map[string]interface{}{
+			"account_name": "Sandbox",
+			"account_id":   "210987654321",
+		},
+		key:      "account_id",
+		otherKey: "123456789012",
+	},
+	"freshservice_azure_subscription": {
+		config: map[string]interface{}{
+			"subscription_name": "Sandbox",
+			"subscription_id":   "00000000-0000-0000-0000-000000000002",
+			"tenant_id":         "00000000-0000-0000-0000-0000000000aa",
+		},
+		key:      "subscription_id",
+		otherKey: "00000000-0000-0000-0000-000000000003",
+	},
+	"freshservice_gcp_project": {
+		config: map[string]interface{}{
+			"project_name": "Sandbox",
+			"project_id":   "sandbox-1234",
+		},
+		key:      "project_id",
+		otherKey: "sandbox-5678",
+	},
+}
+
+// forEachAssetResource runs test as a subtest for every asset-backed resource, with a new
+// harness and a copy of the fixture configuration the test may change
+func forEachAssetResource(t *testing.T, test func(t *testing.T, h *testHarness, name string, fixture assetResourceFixture)) {
+	t.Helper()
+
+	for name, fixture := range assetResourceFixtures {
+		t.Run(name, func(t *testing.T) {
+			fixture.config = maps.Clone(fixture.config)
+			test(t, newTestHarness(t), name, fixture)
+		})
+	}
+}
+
+// requireAttributes fails the test when state attributes differ from the expected values
+func requireAttributes(t *testing.T, state *terraform.InstanceState, expected map[string]string) {
+	t.Helper()
+
+	if state == nil {
+		t.Fatal("state is nil")
+	}
+	for key, want := range expected {
+		if got, ok := state.Attributes[key]; !ok || got != want {
+			t.Errorf("attribute %s = %q, want %q", key, got, want)
+		}
+	}
+}
+
+// requireNoDiags fails the test when diagnostics contain an error
+func requireNoDiags(t *testing.T, diags diag.Diagnostics) {
+	t.Helper()
+
+	if diags.HasError() {
+		t.Fatal(diagsError(diags))
+	}
+}
+
+// diagsError converts error diagnostics into an error
+func diagsError(diags diag.Diagnostics) error {
+	var errs []error
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			errs = append(errs, diagError{d})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// diagError formats a diagnostic with its attribute path
+type diagError struct {
+	diag.Diagnostic
+}
+
+func (e diagError) Error() string {
+	msg := e.Summary
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	if len(e.AttributePath) > 0 {
+		msg = pathString(e.AttributePath) + ": " + msg
+	}
+	return msg
+}
+
+// pathString formats an attribute path like type_fields["po"]
+func pathString(path cty.Path) string {
+	var b strings.Builder
+	for _, step := range path {
+		switch s := step.(type) {
+		case cty.GetAttrStep:
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(s.Name)
+		case cty.IndexStep:
+			if s.Key.Type() == cty.String {
+				fmt.Fprintf(&b, "[%q]", s.Key.AsString())
+			} else {
+				fmt.Fprintf(&b, "[%s]", s.Key.AsBigFloat().String())
+			}
+		}
+	}
+	return b.String()
+}
+
+func TestConfigureProvider(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "api_key")
+	if err := os.WriteFile(keyFile, []byte("file-key\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	envKeyFile := filepath.Join(dir, "env_api_key")
+	if err := os.WriteFile(envKeyFile, []byte("env-file-key"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		config  map[string]interface{}
+		env     map[string]string
+		apiKey  string
+		domain  string
+		baseURL string
+		wantErr string
+		// wantWarning is the summary of the only warning expected
+		wantWarning string
+	}{
+		"arguments": {
+			config: map[string]interface{}{"api_key": "config-key", "domain": "acme"},
+			env:    map[string]string{APIKeyEnvVar: "env-key", DomainEnvVar: "other"},
+			apiKey: "config-key",
+			domain: "acme.freshservice.com",
+		},
+		"key file argument": {
+			config: map[string]interface{}{"api_key_file": keyFile, "domain": "acme"},
+			env:    map[string]string{APIKeyEnvVar: "env-key"},
+			apiKey: "file-key",
+			domain: "acme.freshservice.com",
+		},
+		"environment": {
+			config: map[string]interface{}{},
+			env:    map[string]string{APIKeyEnvVar: "env-key", APIKeyFileEnvVar: envKeyFile, DomainEnvVar: "acme"},
+			apiKey: "env-key",
+			domain: "acme.freshservice.com",
+		},
+		"key file environment": {
+			config: map[string]interface{}{"domain": "acme"},
+			env:    map[string]string{APIKeyFileEnvVar: envKeyFile},
+			apiKey: "env-file-key",
+			domain: "acme.freshservice.com",
+		},
+		"missing key": {
+			config:  map[string]interface{}{"domain": "acme"},
+			wantErr: "no API key configured",
+		},
+		"missing key file": {
+			config:  map[string]interface{}{"api_key_file": filepath.Join(dir, "missing"), "domain": "acme"},
+			wantErr: "failed to read API key file from api_key_file",
+		},
+		"missing domain": {
+			config:  map[string]interface{}{"api_key": "config-key"},
+			wantErr: "no domain configured",
+		},
+		"invalid domain": {
+			config:  map[string]interface{}{"api_key": "config-key", "domain": "https://acme.freshservice.com"},
+			wantErr: "not a URL; use base_url",
+		},
+		"custom domain": {
+			config:      map[string]interface{}{"api_key": "config-key", "domain": "helpdesk.example.com"},
+			apiKey:      "config-key",
+			domain:      "helpdesk.example.com",
+			baseURL:     "https://helpdesk.example.com/api/v2",
+			wantWarning: "Custom domain in domain is deprecated",
+		},
+		"base url": {
+			config:  map[string]interface{}{"api_key": "config-key", "domain": "acme.freshservice.eu", "base_url": "https://helpdesk.example.com/"},
+			apiKey:  "config-key",
+			domain:  "acme.freshservice.eu",
+			baseURL: "https://helpdesk.example.com/api/v2",
+		},
+		"base url without domain": {
+			config:  map[string]interface{}{"api_key": "config-key", "base_url": "http://localhost:8080/api/v2"},
+			apiKey:  "config-key",
+			baseURL: "http://localhost:8080/api/v2",
+		},
+		"insecure base url": {
+			config:  map[string]interface{}{"api_key": "config-key", "base_url": "http://helpdesk.example.com"},
+			wantErr: "base_url: ",
+		},
+		"missing ca bundle": {
+			config:  map[string]interface{}{"api_key": "config-key", "domain": "acme", "ca_bundle_file": filepath.Join(dir, "missing.pem")},
+			wantErr: "invalid proxy_url or ca_bundle_file: failed to read the CA bundle",
+		},
+		"several defaults blocks": {
+			config: map[string]interface{}{"api_key": "config-key", "domain": "acme", "defaults": []interface{}{
+				map[string]interface{}{"managed_by": "Managed by Terraform"},
+				map[string]interface{}{"managed_by": "Managed by OpenTofu"},
+			}},
+			wantErr: "only one defaults block is allowed, got 2",
+		},
+		"too many retries": {
+			config:  map[string]interface{}{"api_key": "config-key", "domain": "acme", "max_retries": 100},
+			wantErr: "expected max_retries to be in the range (0 - 20)",
+		},
+		"key and key file": {
+			config:  map[string]interface{}{"api_key": "config-key", "api_key_file": keyFile, "domain": "acme"},
+			wantErr: "conflicts with",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			for _, key := range []string{APIKeyEnvVar, APIKeyFileEnvVar, DomainEnvVar} {
+				t.Setenv(key, tc.env[key])
+			}
+
+			p := Provider()
+			c := terraform.NewResourceConfigRaw(tc.config)
+			diags := p.Validate(c)
+			if !diags.HasError() {
+				diags = p.Configure(context.Background(), c)
+			}
+
+			if tc.wantErr != "" {
+				if !diags.HasError() || !strings.Contains(diagsError(diags).Error(), tc.wantErr) {
+					t.Fatalf("expected an error containing %q, got %v", tc.wantErr, diagsError(diags))
+				}
+				return
+			}
+			requireNoDiags(t, diags)
+			if tc.wantWarning != "" {
+				if len(diags) != 1 || diags[0].Summary != tc.wantWarning {
+					t.Errorf("expected the warning %q, got %#v", tc.wantWarning, diags)
+				}
+			} else if len(diags) > 0 {
+				t.Errorf("unexpected warnings %#v", diags)
+			}
+
+			config := p.Meta().(*Config)
+			if config.APIKey != tc.apiKey {
+				t.Errorf("api key = %q, want %q", config.APIKey, tc.apiKey)
+			}
+			if config.Domain != tc.domain {
+				t.Errorf("domain = %q, want %q", config.Domain, tc.domain)
+			}
+			if tc.baseURL == "" {
+				tc.baseURL = "https://" + tc.domain + "/api/v2"
+			}
+			if config.BaseURL != tc.baseURL {
+				t.Errorf("base URL = %q, want %q", config.BaseURL, tc.baseURL)
+			}
+		})
+	}
+}
+
+func TestProviderServer_schema(t *testing.T) {
+	newServer, err := ProviderServer(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The mux server fails when the providers serve different provider schemas
+	resp, err := newServer().GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Fatalf("unexpected error: %s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	// Schema versions are served unchanged, so existing state upgrades as before
+	for name, resource := range Provider().ResourcesMap {
+		s, ok := resp.ResourceSchemas[name]
+		if !ok {
+			t.Errorf("expected the server to serve %s", name)
+			continue
+		}
+		if s.Version != int64(resource.SchemaVersion) {
+			t.Errorf("expected %s to have schema version %d, got %d", name, resource.SchemaVersion, s.Version)
+		}
+	}
+	if _, ok := Provider().ResourcesMap["freshservice_asset_type"]; ok {
+		t.Error("expected freshservice_asset_type to be served by the framework provider only")
+	}
+	if s, ok := resp.ResourceSchemas["freshservice_asset_type"]; !ok || s.Version != 0 {
+		t.Errorf("expected the server to serve freshservice_asset_type with schema version 0, got %v", s)
+	}
+	if len(resp.DataSourceSchemas) != len(Provider().DataSourcesMap) {
+		t.Errorf("expected %d data sources, got %d", len(Provider().DataSourcesMap), len(resp.DataSourceSchemas))
+	}
+}
+
+func TestProvider_apiKeySensitive(t *testing.T) {
+	if !Provider().Schema["api_key"].Sensitive {
+		t.Fatal("expected api_key to be sensitive")
+	}
+}
+
+func TestConfigureProvider_userAgent(t *testing.T) {
+	p := Provider()
+	p.TerraformVersion = "1.9.5"
+
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"api_key": testAPIKey,
+		"domain":  "acme",
+	}))
+	requireNoDiags(t, diags)
+
+	config := p.Meta().(*Config)
+	req, err := config.NewRequest(context.Background(), "GET", "/assets", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	userAgent := req.Header.Get("User-Agent")
+	for _, want := range []string{"Terraform/1.9.5", "terraform-provider-freshservice/" + Version} {
+		if !strings.Contains(userAgent, want) {
+			t.Errorf("User-Agent %q does not contain %q", userAgent, want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestConfigureProvider_sharedRateLimit(t *testing.T) {
+	h := newTestHarness(t)
+
+	// 6000 requests per minute space requests 10ms apart after a burst of 10
+	diags := h.provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"api_key":             testAPIKey,
+		"base_url":            h.fake.URL(),
+		"requests_per_minute": 6000,
+	}))
+	requireNoDiags(t, diags)
+	config := h.provider.Meta().(*Config)
+
+	const requests = 30
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, requests)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := config.AssetTypes.Get(context.Background(), 25)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < (requests-10-1)*10*time.Millisecond {
+		t.Errorf("expected %d parallel requests to be spaced out, they took %s", requests, elapsed)
+	}
+}
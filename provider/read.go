@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lcp-llp/terraform-provider-freshservice/freshservice"
+)
+
+// Values of delete_mode
+const (
+	deleteModeTrash     = "trash"
+	deleteModePermanent = "permanent"
+	deleteModeAbandon   = "abandon"
+)
+
+// checkResourceRead handles the error of reading the object backing a resource. When
+// the object was deleted outside of Terraform, the resource is removed from state and
+// false is returned, so the next plan recreates it.
+func checkResourceRead(ctx context.Context, d *schema.ResourceData, err error) (bool, diag.Diagnostics) {
+	if errors.Is(err, freshservice.ErrNotFound) {
+		tflog.Warn(ctx, "Object no longer exists, removing it from state", map[string]interface{}{"id": d.Id()})
+		d.SetId("")
+		return false, nil
+	}
+	if err != nil {
+		return false, diag.FromErr(err)
+	}
+	return true, nil
+}
+
+// checkResourceDelete handles the error of deleting the object backing a resource.
+// An object that is already gone counts as deleted.
+func checkResourceDelete(ctx context.Context, d *schema.ResourceData, err error) diag.Diagnostics {
+	if errors.Is(err, freshservice.ErrNotFound) {
+		tflog.Debug(ctx, "Object is already deleted", map[string]interface{}{"id": d.Id()})
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// resourceIntID returns the ID of a resource backed by an object with a numeric ID,
+// such as the display ID of an asset
+func resourceIntID(d *schema.ResourceData) (int, error) {
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return 0, fmt.Errorf("invalid ID %q, expected a number", d.Id())
+	}
+	return id, nil
+}
+
+// readAssetResource reads the asset backing a resource, identified by its display ID.
+// It returns nil when the asset no longer exists.
+func readAssetResource(ctx context.Context, d *schema.ResourceData, config *Config) (*freshservice.Asset, diag.Diagnostics) {
+	displayID, err := resourceIntID(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	asset, err := config.Assets.Get(ctx, displayID)
+	if errors.Is(err, freshservice.ErrNotFound) {
+		trashed, err := readTrashedAsset(ctx, d, config, displayID)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		if trashed != nil {
+			if err := d.Set("trashed", true); err != nil {
+				return nil, diag.FromErr(err)
+			}
+			return trashed, nil
+		}
+	}
+	if found, diags := checkResourceRead(ctx, d, err); !found {
+		return nil, diags
+	}
+	if err := d.Set("trashed", false); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return asset, nil
+}
+
+// prepareAssetUpdate readies the asset backing a resource for an update, restoring it from
+// the trash and moving it to its new workspace. It returns false when only attributes that
+// don't change the asset itself changed, so there is nothing left to update.
+func prepareAssetUpdate(ctx context.Context, d *schema.ResourceData, config *Config) (bool, diag.Diagnostics) {
+	// delete_mode, restore_if_trashed and adopt_existing don't change the asset itself
+	if !d.HasChangesExcept("delete_mode", "restore_if_trashed", "adopt_existing") {
+		return false, nil
+	}
+
+	// Restore the asset first, as assets in the trash can't be updated
+	if diags := restoreTrashedAsset(ctx, d, config); diags.HasError() {
+		return false, diags
+	}
+
+	// Move the asset first, so the update runs in its new workspace
+	if diags := moveAssetWorkspace(ctx, d, config); diags.HasError() {
+		return false, diags
+	}
+
+	return true, nil
+}
+
+// deleteAssetResource deletes the asset backing a resource as set by its delete_mode
+func deleteAssetResource(ctx context.Context, d *schema.ResourceData, config *Config) diag.Diagnostics {
+	displayID, err := resourceIntID(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	switch d.Get("delete_mode").(string) {
+	case deleteModeAbandon:
+		tflog.Info(ctx, "Leaving the asset in Freshservice, removing it from state", map[string]interface{}{"display_id": displayID})
+		d.SetId("")
+		return nil
+	case deleteModePermanent:
+		// Assets can only be deleted forever from the trash. One that is already in the trash
+		// is not found by the first call.
+		if err := config.Assets.Delete(ctx, displayID); err != nil && !errors.Is(err, freshservice.ErrNotFound) {
+			return diag.FromErr(err)
+		}
+		return checkResourceDelete(ctx, d, config.Assets.DeleteForever(ctx, displayID))
+	default:
+		return checkResourceDelete(ctx, d, config.Assets.Delete(ctx, displayID))
+	}
+}
+
+// assetDeleteModeSchema returns the schema of delete_mode, which sets what happens to
+// the asset of a resource when it is destroyed
+func assetDeleteModeSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringInSlice([]string{deleteModeTrash, deleteModePermanent, deleteModeAbandon}, false),
+		Description:  "What happens to the asset when the resource is destroyed: 'trash' moves it to the trash, 'permanent' deletes it forever, and 'abandon' leaves it in Freshservice (default: trash)",
+	}
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// guardReadOnly makes the create, update and delete of a resource fail before sending
+// any request when the provider is read only. The client refuses writes on its own as
+// well, this only makes the error name the resource.
+func guardReadOnly(name string, r *schema.Resource) {
+	if r.CreateContext != nil {
+		r.CreateContext = readOnlyGuard(name, "create", r.CreateContext)
+	}
+	if r.UpdateContext != nil {
+		r.UpdateContext = readOnlyGuard(name, "update", r.UpdateContext)
+	}
+	if r.DeleteContext != nil {
+		r.DeleteContext = readOnlyGuard(name, "delete", r.DeleteContext)
+	}
+}
+
+// readOnlyGuard wraps a create, update or delete function of the resource name
+func readOnlyGuard(name, operation string, f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if config, ok := meta.(*Config); ok && config.ReadOnly {
+			return readOnlyDiagnostics(name, operation, d.Id())
+		}
+		return f(ctx, d, meta)
+	}
+}
+
+// readOnlyDiagnostics reports a write refused by a read only provider
+func readOnlyDiagnostics(name, operation, id string) diag.Diagnostics {
+	resource := name
+	if id != "" {
+		resource = fmt.Sprintf("%s with ID %s", name, id)
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Cannot %s %s: the provider is read only", operation, resource),
+		Detail:   "The provider is configured with read_only = true, which only allows GET requests to the Freshservice API. Plans and refreshes work, but applying changes requires a provider without read_only.",
+	}}
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/lcp-llp/terraform-provider-freshservice/freshservice"
+)
+
+func TestReadOnly(t *testing.T) {
+	h := newTestHarness(t)
+
+	config := map[string]interface{}{
+		"account_name": "Production",
+		"account_id":   "123456789012",
+	}
+	state := h.apply("freshservice_aws_account", nil, config)
+	displayID, _ := strconv.Atoi(state.ID)
+	before := len(h.fake.Requests())
+
+	h.config.ReadOnly = true
+
+	// Refreshing and planning still work
+	state = h.refresh("freshservice_aws_account", state)
+	h.requireNoPlan("freshservice_aws_account", state, config)
+	if _, err := h.readData("freshservice_asset", map[string]interface{}{"name": "Production"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Writes fail naming the resource
+	_, err := h.tryApply("freshservice_aws_account", nil, map[string]interface{}{"account_name": "Sandbox", "account_id": "210987654321"})
+	if err == nil || !strings.Contains(err.Error(), "Cannot create freshservice_aws_account: the provider is read only") {
+		t.Errorf("expected the create to be refused, got %v", err)
+	}
+
+	config["environment"] = "Staging"
+	_, err = h.tryApply("freshservice_aws_account", state, config)
+	if err == nil || !strings.Contains(err.Error(), "Cannot update freshservice_aws_account with ID "+state.ID) {
+		t.Errorf("expected the update to be refused, got %v", err)
+	}
+
+	// Writes made outside of resources are refused by the client
+	if err := h.config.Assets.Delete(context.Background(), displayID); err == nil || !strings.Contains(err.Error(), "read only") {
+		t.Errorf("expected the delete to be refused, got %v", err)
+	}
+
+	for _, request := range h.fake.Requests()[before:] {
+		if !strings.HasPrefix(request, "GET ") {
+			t.Errorf("expected only GET requests, got %s", request)
+		}
+	}
+}
+
+func TestReadOnly_guardsEveryResource(t *testing.T) {
+	config := &Config{Client: &freshservice.Client{ReadOnly: true}}
+
+	for name, r := range Provider().ResourcesMap {
+		d := r.TestResourceData()
+		d.SetId("42")
+
+		for operation, diags := range map[string]diag.Diagnostics{
+			"create": r.CreateContext(context.Background(), d, config),
+			"update": r.UpdateContext(context.Background(), d, config),
+			"delete": r.DeleteContext(context.Background(), d, config),
+		} {
+			if !diags.HasError() || !strings.Contains(diags[0].Summary, "read only") {
+				t.Errorf("expected %s of %s to be refused, got %v", operation, name, diags)
+			}
+		}
+	}
+}
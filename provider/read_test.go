@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestReadAssetResource_removedOutsideTerraform(t *testing.T) {
+	for removal, remove := range map[string]func(f *fakeFreshservice, displayID int){
+		"deleted": (*fakeFreshservice).DeleteAsset,
+		"trashed": (*fakeFreshservice).TrashAsset,
+	} {
+		t.Run(removal, func(t *testing.T) {
+			forEachAssetResource(t, func(t *testing.T, h *testHarness, name string, fixture assetResourceFixture) {
+				state := h.apply(name, nil, fixture.config)
+				displayID, _ := strconv.Atoi(state.ID)
+				remove(h.fake, displayID)
+
+				if h.refresh(name, state) != nil {
+					t.Fatal("expected the asset to be removed from state")
+				}
+
+				// The next plan recreates the asset
+				diff, err := h.plan(name, nil, fixture.config)
+				if err != nil || diff == nil || diff.Destroy {
+					t.Fatalf("expected a plan creating the asset, got %v (%v)", diff, err)
+				}
+
+				// Deleting an asset that is already gone succeeds
+				h.destroy(name, state)
+			})
+		})
+	}
+}
+
+func TestReadAssetResource_errors(t *testing.T) {
+	h := newTestHarness(t)
+
+	state := h.apply("freshservice_aws_account", nil, map[string]interface{}{
+		"account_name": "Sandbox",
+		"account_id":   "210987654321",
+	})
+
+	// Errors other than 404 are reported and keep the resource in state
+	h.config.MaxRetries = 0
+	h.fake.Fail(1, http.StatusInternalServerError)
+	_, diags := h.resource("freshservice_aws_account").RefreshWithoutUpgrade(context.Background(), state, h.config)
+	if !diags.HasError() || !strings.Contains(diagsError(diags).Error(), "500") {
+		t.Fatalf("expected a server error, got %v", diags)
+	}
+}
+
+func TestDeleteAssetResource_deleteModes(t *testing.T) {
+	testCases := []struct {
+		name       string
+		deleteMode string
+		// before changes the asset outside of Terraform before it is destroyed
+		before func(f *fakeFreshservice, displayID int)
+		check  func(t *testing.T, asset map[string]interface{})
+	}{
+		{
+			name: "default",
+			check: func(t *testing.T, asset map[string]interface{}) {
+				if asset == nil || asset["trashed"] != true {
+					t.Errorf("expected the asset to be in the trash, got %v", asset)
+				}
+			},
+		},
+		{
+			name:       "permanent",
+			deleteMode: deleteModePermanent,
+			check: func(t *testing.T, asset map[string]interface{}) {
+				if asset != nil {
+					t.Errorf("expected the asset to be deleted forever, got %v", asset)
+				}
+			},
+		},
+		{
+			name:       "permanent when already in the trash",
+			deleteMode: deleteModePermanent,
+			before:     (*fakeFreshservice).TrashAsset,
+			check: func(t *testing.T, asset map[string]interface{}) {
+				if asset != nil {
+					t.Errorf("expected the asset to be deleted forever, got %v", asset)
+				}
+			},
+		},
+		{
+			name:       "permanent when already deleted",
+			deleteMode: deleteModePermanent,
+			before:     (*fakeFreshservice).DeleteAsset,
+			check:      func(t *testing.T, asset map[string]interface{}) {},
+		},
+		{
+			name:       "abandon",
+			deleteMode: deleteModeAbandon,
+			check: func(t *testing.T, asset map[string]interface{}) {
+				if asset == nil || asset["trashed"] == true {
+					t.Errorf("expected the asset to be left alone, got %v", asset)
+				}
+			},
+		},
+	}
+
+	for _, name := range []string{"freshservice_asset", "freshservice_aws_account"} {
+		for _, tc := range testCases {
+			t.Run(name+"/"+tc.name, func(t *testing.T) {
+				h := newTestHarness(t)
+
+				config := maps.Clone(assetResourceFixtures[name].config)
+				if tc.deleteMode != "" {
+					config["delete_mode"] = tc.deleteMode
+				}
+
+				state := h.apply(name, nil, config)
+				displayID, _ := strconv.Atoi(state.ID)
+				if tc.before != nil {
+					tc.before(h.fake, displayID)
+				}
+
+				h.destroy(name, state)
+				tc.check(t, h.fake.Asset(displayID))
+			})
+		}
+	}
+}
+
+func TestDeleteAssetResource_changeDeleteMode(t *testing.T) {
+	h := newTestHarness(t)
+
+	config := map[string]interface{}{"name": "Monitor", "asset_type_id": testHardwareTypeID}
+	state := h.apply("freshservice_asset", nil, config)
+	before := h.fake.CountRequests("PUT ")
+
+	// Changing delete_mode only updates state
+	config["delete_mode"] = deleteModeAbandon
+	state = h.apply("freshservice_asset", state, config)
+	requireAttributes(t, state, map[string]string{"delete_mode": deleteModeAbandon})
+	if n := h.fake.CountRequests("PUT ") - before; n != 0 {
+		t.Errorf("expected no update requests, got %d", n)
+	}
+	h.requireNoPlan("freshservice_asset", h.refresh("freshservice_asset", state), config)
+}
@@ -1,70 +1,35 @@
 package provider
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lcp-llp/terraform-provider-freshservice/freshservice"
 )
 
-// Asset represents a Freshservice asset
-type Asset struct {
-	ID                  int                    `json:"id"`
-	DisplayID           int                    `json:"display_id"`
-	Name                string                 `json:"name"`
-	Description         string                 `json:"description"`
-	AssetTypeID         int                    `json:"asset_type_id"`
-	Impact              string                 `json:"impact"`
-	AuthorType          string                 `json:"author_type"`
-	UsageType           string                 `json:"usage_type"`
-	AssetTag            string                 `json:"asset_tag"`
-	UserID              *int                   `json:"user_id"`
-	LocationID          *int                   `json:"location_id"`
-	DepartmentID        *int                   `json:"department_id"`
-	AgentID             *int                   `json:"agent_id"`
-	GroupID             *int                   `json:"group_id"`
-	AssignedOn          *string                `json:"assigned_on"`
-	CreatedAt           time.Time              `json:"created_at"`
-	UpdatedAt           time.Time              `json:"updated_at"`
-	WorkspaceID         int                    `json:"workspace_id"`
-	CreatedBySource     string                 `json:"created_by_source"`
-	LastUpdatedBySource string                 `json:"last_updated_by_source"`
-	CreatedByUser       *int                   `json:"created_by_user"`
-	LastUpdatedByUser   *int                   `json:"last_updated_by_user"`
-	Sources             []string               `json:"sources"`
-	SerialNumber        string                 `json:"serial_number,omitempty"`
-	MacAddresses        []string               `json:"mac_addresses,omitempty"`
-	IPAddresses         []string               `json:"ip_addresses,omitempty"`
-	UUID                string                 `json:"uuid,omitempty"`
-	ItemID              string                 `json:"item_id,omitempty"`
-	IMEINumber          string                 `json:"imei_number,omitempty"`
-	TypeFields          map[string]interface{} `json:"type_fields,omitempty"`
-}
-
-// AssetResponse represents the API response for asset operations
-type AssetResponse struct {
-	Asset Asset `json:"asset"`
-}
-
-// AssetRequest represents the request body for asset operations
-type AssetRequest struct {
-	Name         string                 `json:"name"`
-	Description  string                 `json:"description,omitempty"`
-	AssetTypeID  int                    `json:"asset_type_id"`
-	Impact       string                 `json:"impact,omitempty"`
-	UsageType    string                 `json:"usage_type,omitempty"`
-	UserID       *int                   `json:"user_id,omitempty"`
-	LocationID   *int                   `json:"location_id,omitempty"`
-	DepartmentID *int                   `json:"department_id,omitempty"`
-	AgentID      *int                   `json:"agent_id,omitempty"`
-	GroupID      *int                   `json:"group_id,omitempty"`
-	TypeFields   map[string]interface{} `json:"type_fields,omitempty"`
+// assetAPIFields maps asset fields reported in API errors to resource attributes
+var assetAPIFields = map[string]string{
+	"name":          "name",
+	"description":   "description",
+	"asset_type_id": "asset_type_id",
+	"impact":        "impact",
+	"usage_type":    "usage_type",
+	"asset_tag":     "asset_tag",
+	"user_id":       "user_id",
+	"location_id":   "location_id",
+	"department_id": "department_id",
+	"agent_id":      "agent_id",
+	"group_id":      "group_id",
+	"workspace_id":  "workspace_id",
 }
 
 func resourceAsset() *schema.Resource {
@@ -73,8 +38,9 @@ func resourceAsset() *schema.Resource {
 		ReadContext:   resourceAssetRead,
 		UpdateContext: resourceAssetUpdate,
 		DeleteContext: resourceAssetDelete,
+		CustomizeDiff: customdiff.All(resourceAssetCustomizeDiff, customizeTrashedAsset),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importAssetState(0),
 		},
 		Description: "Manages a Freshservice asset with custom type fields",
 
@@ -140,11 +106,16 @@ func resourceAsset() *schema.Resource {
 			"type_fields": {
 				Type:        schema.TypeMap,
 				Optional:    true,
-				Description: "Custom type fields specific to the asset type. Field names will automatically have the asset type ID appended (e.g., 'product' becomes 'product_25')",
+				Description: "Custom type fields specific to the asset type. Field names will automatically have the asset type ID appended (e.g., 'product' becomes 'product_25'). Values are converted based on the field's data type",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
+			"delete_mode":        assetDeleteModeSchema(),
+			"adopt_existing":     assetAdoptExistingSchema("asset_tag"),
+			"restore_if_trashed": assetRestoreIfTrashedSchema(),
+			"trashed":            assetTrashedSchema(),
+			"type_fields_all":    typeFieldsAllSchema(),
 			// Computed fields
 			"display_id": {
 				Type:        schema.TypeInt,
@@ -153,8 +124,9 @@ func resourceAsset() *schema.Resource {
 			},
 			"asset_tag": {
 				Type:        schema.TypeString,
+				Optional:    true,
 				Computed:    true,
-				Description: "Asset tag",
+				Description: "Asset tag. Freshservice generates one when it is not set",
 			},
 			"author_type": {
 				Type:        schema.TypeString,
@@ -177,9 +149,11 @@ func resourceAsset() *schema.Resource {
 				Description: "Last update timestamp of the asset",
 			},
 			"workspace_id": {
-				Type:        schema.TypeInt,
-				Computed:    true,
-				Description: "Workspace ID of the asset",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Workspace ID of the asset. Defaults to the provider's default_workspace_id, or the default workspace of the account. Changing this moves the asset to the new workspace",
 			},
 			"created_by_source": {
 				Type:        schema.TypeString,
@@ -249,153 +223,148 @@ func resourceAsset() *schema.Resource {
 	}
 }
 
-func resourceAssetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
-
-	// Build type_fields from the type_fields map
-	typeFields := make(map[string]interface{})
-	if typeFieldsRaw, ok := d.GetOk("type_fields"); ok {
-		assetTypeID := d.Get("asset_type_id").(int)
-		for key, value := range typeFieldsRaw.(map[string]interface{}) {
-			// Automatically append the asset type ID to the field name
-			fieldKey := fmt.Sprintf("%s_%d", key, assetTypeID)
-			// Convert string values to appropriate types based on common patterns
-			typeFields[fieldKey] = convertTypeFieldValue(value.(string))
-		}
-	}
-
-	// Build request body
-	assetReq := AssetRequest{
-		Name:        d.Get("name").(string),
-		Description: d.Get("description").(string),
-		AssetTypeID: d.Get("asset_type_id").(int),
-		Impact:      d.Get("impact").(string),
-		UsageType:   d.Get("usage_type").(string),
-		TypeFields:  typeFields,
-	}
-
-	// Handle optional nullable fields
-	if userID, ok := d.GetOk("user_id"); ok {
-		uid := userID.(int)
-		assetReq.UserID = &uid
-	}
-	if locationID, ok := d.GetOk("location_id"); ok {
-		lid := locationID.(int)
-		assetReq.LocationID = &lid
-	}
-	if departmentID, ok := d.GetOk("department_id"); ok {
-		did := departmentID.(int)
-		assetReq.DepartmentID = &did
-	}
-	if agentID, ok := d.GetOk("agent_id"); ok {
-		aid := agentID.(int)
-		assetReq.AgentID = &aid
-	}
-	if groupID, ok := d.GetOk("group_id"); ok {
-		gid := groupID.(int)
-		assetReq.GroupID = &gid
+// resourceAssetCustomizeDiff plans type_fields_all with the type fields set by the provider defaults
+func resourceAssetCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("asset_type_id") || !d.NewValueKnown("type_fields") {
+		return d.SetNewComputed("type_fields_all")
 	}
 
-	// Convert request to JSON
-	jsonData, err := json.Marshal(assetReq)
+	config := meta.(*Config)
+	fields, err := config.getAssetTypeFields(ctx, d.Get("asset_type_id").(int))
 	if err != nil {
-		return diag.Errorf("Failed to marshal request: %s", err)
+		return err
 	}
 
-	// Create the request
-	req, err := config.NewRequest(ctx, "POST", "/assets", bytes.NewReader(jsonData))
-	if err != nil {
-		return diag.FromErr(err)
-	}
+	return d.SetNew("type_fields_all", config.Defaults.mergeTypeFields(fields, d.Get("type_fields").(map[string]interface{})))
+}
 
-	// Execute the request
-	resp, err := config.DoRequest(req)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	defer resp.Body.Close()
+func resourceAssetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
 
-	// Parse response
-	var assetResp AssetResponse
-	if err := json.NewDecoder(resp.Body).Decode(&assetResp); err != nil {
-		return diag.Errorf("Failed to decode response: %s", err)
+	// Take over an existing asset with the same asset tag instead of creating a duplicate
+	existing, diags := adoptAssetByTag(ctx, d, config)
+	if diags.HasError() {
+		return diags
+	}
+	if existing != nil {
+		d.SetId(strconv.Itoa(existing.DisplayID))
+		return resourceAssetUpdate(ctx, d, meta)
 	}
 
-	// Set the resource ID using display_id
-	d.SetId(strconv.Itoa(assetResp.Asset.DisplayID))
-
-	return setAssetData(d, &assetResp.Asset)
+	return writeAsset(ctx, d, config, assetKind)
 }
 
 func resourceAssetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
 
-	// Get the asset display ID (stored as Terraform resource ID)
-	displayID := d.Id()
-
-	// Create the request using display_id
-	endpoint := fmt.Sprintf("/assets/%s", displayID)
-	req, err := config.NewRequest(ctx, "GET", endpoint, nil)
-	if err != nil {
-		return diag.Errorf("Failed to create request for asset %s: %s", displayID, err)
-	}
-
-	// Execute the request
-	resp, err := config.DoRequest(req)
-	if err != nil {
-		return diag.Errorf("Request failed for asset %s: %s", displayID, err)
+	asset, diags := readAssetResource(ctx, d, config)
+	if asset == nil {
+		return diags
 	}
-	defer resp.Body.Close()
 
-	// Check for 404 specifically
-	if resp.StatusCode == 404 {
-		d.SetId("")
-		return nil
-	}
+	return setAssetResourceData(ctx, d, config, assetKind, asset)
+}
 
-	// Check for other non-200 status codes
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return diag.Errorf("API request failed with status %d for asset %s", resp.StatusCode, displayID)
-	}
+func resourceAssetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
 
-	// Parse response
-	var assetResp AssetResponse
-	if err := json.NewDecoder(resp.Body).Decode(&assetResp); err != nil {
-		return diag.Errorf("Failed to decode response for asset %s: %s", displayID, err)
+	if update, diags := prepareAssetUpdate(ctx, d, config); !update {
+		return diags
 	}
 
-	return setAssetData(d, &assetResp.Asset)
+	return writeAsset(ctx, d, config, assetKind)
 }
 
-func resourceAssetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceAssetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
+	return deleteAssetResource(ctx, d, config)
+}
+
+// assetResourceKind holds what sets freshservice_asset and freshservice_cloud_asset apart.
+// Otherwise both resources write and read their asset the same way.
+type assetResourceKind struct {
+	// name is the kind of asset in log messages
+	name string
+	// apiFields maps asset fields reported in API errors to resource attributes
+	apiFields map[string]string
+	// expandTypeFields converts the configured type_fields for the API. It returns the
+	// values keyed by full field name and the configured key of each full field name.
+	expandTypeFields func(fields *assetTypeFieldSet, raw map[string]interface{}) (map[string]interface{}, map[string]string, error)
+	// expand adds the attributes only this resource has to the request
+	expand func(d *schema.ResourceData, assetReq *freshservice.AssetRequest)
+	// flatten sets the attributes only this resource has
+	flatten func(d *schema.ResourceData, asset *freshservice.Asset) error
+}
 
-	// Get the asset display ID (stored as Terraform resource ID)
-	displayID := d.Id()
+// assetKind is the kind of freshservice_asset, which sends unknown type fields as configured
+var assetKind = assetResourceKind{
+	name:             "asset",
+	apiFields:        assetAPIFields,
+	expandTypeFields: expandAssetTypeFields,
+	expand:           expandAssetAssignment,
+	flatten:          flattenAssetDetails,
+}
 
-	// Build type_fields from the type_fields map
-	typeFields := make(map[string]interface{})
-	if typeFieldsRaw, ok := d.GetOk("type_fields"); ok {
-		assetTypeID := d.Get("asset_type_id").(int)
-		for key, value := range typeFieldsRaw.(map[string]interface{}) {
-			// Automatically append the asset type ID to the field name
-			fieldKey := fmt.Sprintf("%s_%d", key, assetTypeID)
-			// Convert string values to appropriate types based on common patterns
-			typeFields[fieldKey] = convertTypeFieldValue(value.(string))
-		}
+// writeAsset creates the asset of a freshservice_asset or freshservice_cloud_asset resource
+// when it has no ID yet, and otherwise updates the asset with all configured values
+func writeAsset(ctx context.Context, d *schema.ResourceData, config *Config, kind assetResourceKind) diag.Diagnostics {
+	assetTypeID := d.Get("asset_type_id").(int)
+	create := d.Id() == ""
+
+	if create {
+		tflog.Debug(ctx, "Creating "+kind.name, map[string]interface{}{"asset_type_id": assetTypeID})
+	} else {
+		tflog.Debug(ctx, "Updating "+kind.name, map[string]interface{}{"display_id": d.Id(), "asset_type_id": assetTypeID})
+	}
+
+	// Build type_fields from the type_fields map, converting values based on the field definitions
+	fields, err := config.getAssetTypeFields(ctx, assetTypeID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	typeFields, keys, err := kind.expandTypeFields(fields, config.Defaults.mergeTypeFields(fields, d.Get("type_fields").(map[string]interface{})))
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	// Build request body with all current values
-	assetReq := AssetRequest{
+	assetReq := &freshservice.AssetRequest{
 		Name:        d.Get("name").(string),
-		Description: d.Get("description").(string),
-		AssetTypeID: d.Get("asset_type_id").(int),
+		Description: config.Defaults.stampDescription(d.Get("description").(string)),
+		AssetTypeID: assetTypeID,
 		Impact:      d.Get("impact").(string),
 		UsageType:   d.Get("usage_type").(string),
+		AssetTag:    d.Get("asset_tag").(string),
 		TypeFields:  typeFields,
 	}
+	if kind.expand != nil {
+		kind.expand(d, assetReq)
+	}
+
+	var asset *freshservice.Asset
+	if create {
+		assetReq.WorkspaceID = assetWorkspaceID(d, config)
+		asset, err = config.Assets.Create(ctx, assetReq)
+	} else {
+		var displayID int
+		if displayID, err = resourceIntID(d); err != nil {
+			return diag.FromErr(err)
+		}
+		asset, err = config.Assets.Update(ctx, displayID, assetReq)
+	}
+	if err != nil {
+		return apiErrorDiagnostics(err, configuredTypeFieldResolver(kind.apiFields, keys))
+	}
+
+	// Set the resource ID using display_id
+	d.SetId(strconv.Itoa(asset.DisplayID))
+
+	return setAssetResourceData(ctx, d, config, kind, asset)
+}
 
-	// Handle optional nullable fields
+// expandAssetAssignment adds the user, location, department, agent and group of a
+// freshservice_asset to the request, when they are set
+func expandAssetAssignment(d *schema.ResourceData, assetReq *freshservice.AssetRequest) {
 	if userID, ok := d.GetOk("user_id"); ok {
 		uid := userID.(int)
 		assetReq.UserID = &uid
@@ -416,79 +385,18 @@ func resourceAssetUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 		gid := groupID.(int)
 		assetReq.GroupID = &gid
 	}
-
-	// Convert request to JSON
-	jsonData, err := json.Marshal(assetReq)
-	if err != nil {
-		return diag.Errorf("Failed to marshal request: %s", err)
-	}
-
-	// Create the request
-	endpoint := fmt.Sprintf("/assets/%s", displayID)
-	req, err := config.NewRequest(ctx, "PUT", endpoint, bytes.NewReader(jsonData))
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	// Execute the request
-	resp, err := config.DoRequest(req)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	defer resp.Body.Close()
-
-	// Parse response
-	var assetResp AssetResponse
-	if err := json.NewDecoder(resp.Body).Decode(&assetResp); err != nil {
-		return diag.Errorf("Failed to decode response: %s", err)
-	}
-
-	return setAssetData(d, &assetResp.Asset)
 }
 
-func resourceAssetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
-
-	// Get the asset display ID (stored as Terraform resource ID)
-	displayID := d.Id()
-
-	// Create the request
-	endpoint := fmt.Sprintf("/assets/%s", displayID)
-	req, err := config.NewRequest(ctx, "DELETE", endpoint, nil)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	// Execute the request
-	resp, err := config.DoRequest(req)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	defer resp.Body.Close()
-
-	// If asset not found, it's already deleted
-	if resp.StatusCode == 404 {
-		d.SetId("")
-		return nil
-	}
-
-	// Check for other errors
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return diag.Errorf("Failed to delete asset: API returned status %d", resp.StatusCode)
-	}
-
-	// Clear the resource ID
-	d.SetId("")
-
-	return nil
-}
+// setAssetResourceData sets the asset data of a freshservice_asset or
+// freshservice_cloud_asset resource in the Terraform state
+func setAssetResourceData(ctx context.Context, d *schema.ResourceData, config *Config, kind assetResourceKind, asset *freshservice.Asset) diag.Diagnostics {
+	// asset_type_id is required, so it is only missing from state during import
+	importing := d.Get("asset_type_id").(int) == 0
 
-// setAssetData sets the asset data in the Terraform state
-func setAssetData(d *schema.ResourceData, asset *Asset) diag.Diagnostics {
 	if err := d.Set("name", asset.Name); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("description", asset.Description); err != nil {
+	if err := d.Set("description", config.Defaults.unstampDescription(asset.Description)); err != nil {
 		return diag.FromErr(err)
 	}
 	if err := d.Set("asset_type_id", asset.AssetTypeID); err != nil {
@@ -506,9 +414,6 @@ func setAssetData(d *schema.ResourceData, asset *Asset) diag.Diagnostics {
 	if err := d.Set("asset_tag", asset.AssetTag); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("author_type", asset.AuthorType); err != nil {
-		return diag.FromErr(err)
-	}
 	if err := d.Set("created_at", asset.CreatedAt.Format(time.RFC3339)); err != nil {
 		return diag.FromErr(err)
 	}
@@ -518,115 +423,125 @@ func setAssetData(d *schema.ResourceData, asset *Asset) diag.Diagnostics {
 	if err := d.Set("workspace_id", asset.WorkspaceID); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("created_by_source", asset.CreatedBySource); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("last_updated_by_source", asset.LastUpdatedBySource); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("sources", asset.Sources); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("serial_number", asset.SerialNumber); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("mac_addresses", asset.MacAddresses); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("ip_addresses", asset.IPAddresses); err != nil {
-		return diag.FromErr(err)
+	if kind.flatten != nil {
+		if err := kind.flatten(d, asset); err != nil {
+			return diag.FromErr(err)
+		}
 	}
-	if err := d.Set("uuid", asset.UUID); err != nil {
+
+	// Set type_fields - convert back to strings using the field definitions
+	// Strip the asset type ID suffix from field names
+	fields, err := config.getAssetTypeFields(ctx, asset.AssetTypeID)
+	if err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("item_id", asset.ItemID); err != nil {
+	typeFields := flattenAssetTypeFields(fields, asset.TypeFields, d.Get("type_fields").(map[string]interface{}), importing)
+	if err := d.Set("type_fields", typeFields); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("imei_number", asset.IMEINumber); err != nil {
+
+	typeFieldsAll := config.Defaults.mergeTypeFields(fields, d.Get("type_fields").(map[string]interface{}))
+	if err := d.Set("type_fields_all", flattenAssetTypeFields(fields, asset.TypeFields, typeFieldsAll, importing)); err != nil {
 		return diag.FromErr(err)
 	}
 
-	// Handle nullable fields
-	if asset.UserID != nil {
-		if err := d.Set("user_id", *asset.UserID); err != nil {
-			return diag.FromErr(err)
-		}
-	}
-	if asset.LocationID != nil {
-		if err := d.Set("location_id", *asset.LocationID); err != nil {
-			return diag.FromErr(err)
-		}
-	}
-	if asset.DepartmentID != nil {
-		if err := d.Set("department_id", *asset.DepartmentID); err != nil {
-			return diag.FromErr(err)
+	return nil
+}
+
+// flattenAssetDetails sets the attributes only freshservice_asset reads from the asset
+func flattenAssetDetails(d *schema.ResourceData, asset *freshservice.Asset) error {
+	for attr, value := range map[string]interface{}{
+		"author_type":            asset.AuthorType,
+		"created_by_source":      asset.CreatedBySource,
+		"last_updated_by_source": asset.LastUpdatedBySource,
+		"sources":                asset.Sources,
+		"serial_number":          asset.SerialNumber,
+		"mac_addresses":          asset.MacAddresses,
+		"ip_addresses":           asset.IPAddresses,
+		"uuid":                   asset.UUID,
+		"item_id":                asset.ItemID,
+		"imei_number":            asset.IMEINumber,
+	} {
+		if err := d.Set(attr, value); err != nil {
+			return err
 		}
 	}
-	if asset.AgentID != nil {
-		if err := d.Set("agent_id", *asset.AgentID); err != nil {
-			return diag.FromErr(err)
+
+	// Handle nullable fields
+	for attr, value := range map[string]*int{
+		"user_id":              asset.UserID,
+		"location_id":          asset.LocationID,
+		"department_id":        asset.DepartmentID,
+		"agent_id":             asset.AgentID,
+		"group_id":             asset.GroupID,
+		"created_by_user":      asset.CreatedByUser,
+		"last_updated_by_user": asset.LastUpdatedByUser,
+	} {
+		if value == nil {
+			continue
 		}
-	}
-	if asset.GroupID != nil {
-		if err := d.Set("group_id", *asset.GroupID); err != nil {
-			return diag.FromErr(err)
+		if err := d.Set(attr, *value); err != nil {
+			return err
 		}
 	}
 	if asset.AssignedOn != nil {
 		if err := d.Set("assigned_on", *asset.AssignedOn); err != nil {
-			return diag.FromErr(err)
+			return err
 		}
 	}
-	if asset.CreatedByUser != nil {
-		if err := d.Set("created_by_user", *asset.CreatedByUser); err != nil {
-			return diag.FromErr(err)
+
+	return nil
+}
+
+// expandAssetTypeFields converts configured type_fields for the API. Keys get the asset
+// type ID appended unless they match a field of the asset type (or of a parent type).
+// Values are only converted for number, decimal, checkbox and date fields; everything
+// else, including keys without a field definition, is sent exactly as configured.
+// It also returns the configured key of each full field name.
+func expandAssetTypeFields(fields *assetTypeFieldSet, raw map[string]interface{}) (map[string]interface{}, map[string]string, error) {
+	typeFields := make(map[string]interface{})
+	keys := make(map[string]string)
+	var errs []error
+
+	for key, value := range raw {
+		strValue := value.(string)
+
+		field, ok := fields.Lookup(key)
+		if !ok {
+			name := fmt.Sprintf("%s_%d", key, fields.AssetTypeID)
+			typeFields[name] = strValue
+			keys[name] = key
+			continue
 		}
-	}
-	if asset.LastUpdatedByUser != nil {
-		if err := d.Set("last_updated_by_user", *asset.LastUpdatedByUser); err != nil {
-			return diag.FromErr(err)
+
+		converted, err := field.ConvertValue(strValue)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("type_fields.%s: invalid %s value: %s", key, field.DataType, err))
+			continue
 		}
+		typeFields[field.Name] = converted
+		keys[field.Name] = key
 	}
 
-	// Set type_fields - convert back to map[string]string for Terraform
-	// Strip the asset type ID suffix from field names
-	if asset.TypeFields != nil {
-		typeFieldsMap := make(map[string]string)
-		assetTypeIDSuffix := fmt.Sprintf("_%d", asset.AssetTypeID)
-
-		for key, value := range asset.TypeFields {
-			// Remove the asset type ID suffix if present
-			cleanKey := key
-			if strings.HasSuffix(key, assetTypeIDSuffix) {
-				cleanKey = strings.TrimSuffix(key, assetTypeIDSuffix)
-			}
-			typeFieldsMap[cleanKey] = fmt.Sprintf("%v", value)
-		}
-		if err := d.Set("type_fields", typeFieldsMap); err != nil {
-			return diag.FromErr(err)
-		}
+	if len(errs) > 0 {
+		return nil, nil, errors.Join(errs...)
 	}
 
-	return nil
+	return typeFields, keys, nil
 }
 
-// convertTypeFieldValue converts string values to appropriate types for the API
-func convertTypeFieldValue(value string) interface{} {
-	// Try to convert to int
-	if intVal, err := strconv.Atoi(value); err == nil {
-		return intVal
-	}
+// flattenTypeFields converts type fields from the API to map[string]string for Terraform,
+// stripping the asset type ID suffix from field names and skipping empty fields
+func flattenTypeFields(typeFields map[string]interface{}, assetTypeID int) map[string]string {
+	typeFieldsMap := make(map[string]string)
+	assetTypeIDSuffix := fmt.Sprintf("_%d", assetTypeID)
 
-	// Try to convert to float
-	if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
-		return floatVal
-	}
-
-	// Try to convert to bool
-	if boolVal, err := strconv.ParseBool(value); err == nil {
-		return boolVal
+	for key, value := range typeFields {
+		if value == nil {
+			continue
+		}
+		typeFieldsMap[strings.TrimSuffix(key, assetTypeIDSuffix)] = freshservice.FormatFieldValue(value)
 	}
 
-	// Return as string if no conversion is possible
-	return value
+	return typeFieldsMap
 }
@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestResourceAsset_lifecycle(t *testing.T) {
+	h := newTestHarness(t)
+
+	config := map[string]interface{}{
+		"name":          "Dell Latitude 5520",
+		"description":   "Developer laptop",
+		"asset_type_id": testHardwareTypeID,
+		"impact":        "medium",
+		"type_fields": map[string]interface{}{
+			"product":         "Dell Latitude",
+			"po_number":       "00123",
+			"quantity":        "3",
+			"cost":            "1250.50",
+			"warranty_expiry": "2027-06-30",
+			"managed":         "true",
+			"environment":     "Production",
+		},
+	}
+
+	// Create
+	state := h.apply("freshservice_asset", nil, config)
+	requireAttributes(t, state, map[string]string{
+		"name":                        "Dell Latitude 5520",
+		"impact":                      "medium",
+		"usage_type":                  "permanent",
+		"type_fields.%":               "7",
+		"type_fields.po_number":       "00123",
+		"type_fields.cost":            "1250.50",
+		"type_fields.managed":         "true",
+		"type_fields.warranty_expiry": "2027-06-30",
+	})
+
+	// Values are sent with the type of their field, strings exactly as written
+	displayID, _ := strconv.Atoi(state.ID)
+	typeFields := h.fake.Asset(displayID)["type_fields"].(map[string]interface{})
+	for name, want := range map[string]interface{}{
+		"product_25":   "Dell Latitude",
+		"po_number_25": "00123",
+		"quantity_25":  float64(3),
+		"cost_25":      1250.5,
+		"managed_25":   true,
+	} {
+		if got := typeFields[name]; got != want {
+			t.Errorf("type field %s = %#v, want %#v", name, got, want)
+		}
+	}
+
+	// Read
+	state = h.refresh("freshservice_asset", state)
+	h.requireNoPlan("freshservice_asset", state, config)
+
+	// Update
+	config["name"] = "Dell Latitude 5530"
+	config["type_fields"].(map[string]interface{})["quantity"] = "4"
+	state = h.apply("freshservice_asset", state, config)
+	requireAttributes(t, state, map[string]string{
+		"id":                   strconv.Itoa(displayID),
+		"name":                 "Dell Latitude 5530",
+		"type_fields.quantity": "4",
+	})
+	h.requireNoPlan("freshservice_asset", h.refresh("freshservice_asset", state), config)
+
+	// Drift
+	h.fake.UpdateAsset(displayID, map[string]interface{}{"name": "Renamed in Freshservice"}, map[string]interface{}{"cost_25": 99.0})
+	state = h.refresh("freshservice_asset", state)
+	requireAttributes(t, state, map[string]string{
+		"name":             "Renamed in Freshservice",
+		"type_fields.cost": "99",
+	})
+	if diff, err := h.plan("freshservice_asset", state, config); err != nil || diff == nil {
+		t.Fatalf("expected a plan correcting the drift, got %v (%v)", diff, err)
+	}
+	state = h.apply("freshservice_asset", state, config)
+	h.requireNoPlan("freshservice_asset", h.refresh("freshservice_asset", state), config)
+
+	// Import
+	imported := h.importState("freshservice_asset", state.ID)
+	requireAttributes(t, imported, map[string]string{
+		"name":                  "Dell Latitude 5530",
+		"asset_type_id":         "25",
+		"type_fields.po_number": "00123",
+		"type_fields.cost":      "1250.5",
+	})
+
+	// Delete
+	h.destroy("freshservice_asset", state)
+	if asset := h.fake.Asset(displayID); asset["trashed"] != true {
+		t.Errorf("expected asset %d to be in trash", displayID)
+	}
+	if h.refresh("freshservice_asset", state) != nil {
+		t.Error("expected the trashed asset to be removed from state")
+	}
+}
+
+func TestResourceAsset_deletedOutsideTerraform(t *testing.T) {
+	h := newTestHarness(t)
+
+	state := h.apply("freshservice_asset", nil, map[string]interface{}{
+		"name":          "Monitor",
+		"asset_type_id": testHardwareTypeID,
+	})
+
+	displayID, _ := strconv.Atoi(state.ID)
+	h.fake.DeleteAsset(displayID)
+
+	if h.refresh("freshservice_asset", state) != nil {
+		t.Fatal("expected the deleted asset to be removed from state")
+	}
+}
+
+func TestResourceAsset_typeFieldErrors(t *testing.T) {
+	h := newTestHarness(t)
+
+	// Values that don't match the field type are rejected before sending
+	_, err := h.tryApply("freshservice_asset", nil, map[string]interface{}{
+		"name":          "Monitor",
+		"asset_type_id": testHardwareTypeID,
+		"type_fields":   map[string]interface{}{"quantity": "three"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "type_fields.quantity") {
+		t.Fatalf("expected a quantity validation error, got %v", err)
+	}
+	if n := h.fake.CountRequests("POST /api/v2/assets"); n != 0 {
+		t.Fatalf("expected no create request, got %d", n)
+	}
+
+	// Field errors returned by the API point at the type_fields key
+	_, err = h.tryApply("freshservice_asset", nil, map[string]interface{}{
+		"name":          "Monitor",
+		"asset_type_id": testHardwareTypeID,
+		"type_fields":   map[string]interface{}{"bogus": "value"},
+	})
+	if err == nil || !strings.Contains(err.Error(), `type_fields["bogus"]`) {
+		t.Fatalf("expected an error for type_fields[\"bogus\"], got %v", err)
+	}
+}
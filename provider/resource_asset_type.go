@@ -1,86 +1,98 @@
 package provider
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lcp-llp/terraform-provider-freshservice/freshservice"
 )
 
-// AssetType represents a Freshservice asset type
-type AssetType struct {
-	ID                int       `json:"id"`
-	Name              string    `json:"name"`
-	ParentAssetTypeID *int      `json:"parent_asset_type_id"`
-	Description       string    `json:"description"`
-	Visible           bool      `json:"visible"`
-	CreatedAt         time.Time `json:"created_at"`
-	UpdatedAt         time.Time `json:"updated_at"`
+// assetTypeAPIFields maps asset type fields reported in API errors to resource attributes
+var assetTypeAPIFields = map[string]string{
+	"name":                 "name",
+	"description":          "description",
+	"parent_asset_type_id": "parent_asset_type_id",
+	"visible":              "visible",
 }
 
-// AssetTypeResponse represents the API response for asset type operations
-type AssetTypeResponse struct {
-	AssetType AssetType `json:"asset_type"`
+// assetTypeResource is freshservice_asset_type, the first resource served by
+// terraform-plugin-framework. Its schema and state match the SDKv2 resource it replaces.
+type assetTypeResource struct {
+	config *Config
 }
 
-// AssetTypeRequest represents the request body for asset type operations
-type AssetTypeRequest struct {
-	Name              string `json:"name,omitempty"`
-	ParentAssetTypeID *int   `json:"parent_asset_type_id,omitempty"`
-	Description       string `json:"description,omitempty"`
-	Visible           *bool  `json:"visible,omitempty"`
+// assetTypeResourceModel is the state of freshservice_asset_type
+type assetTypeResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	Description       types.String `tfsdk:"description"`
+	ParentAssetTypeID types.Int64  `tfsdk:"parent_asset_type_id"`
+	Visible           types.Bool   `tfsdk:"visible"`
+	CreatedAt         types.String `tfsdk:"created_at"`
+	UpdatedAt         types.String `tfsdk:"updated_at"`
 }
 
-func resourceAssetType() *schema.Resource {
-	return &schema.Resource{
-		CreateContext: resourceAssetTypeCreate,
-		ReadContext:   resourceAssetTypeRead,
-		UpdateContext: resourceAssetTypeUpdate,
-		DeleteContext: resourceAssetTypeDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+func newAssetTypeResource() resource.Resource {
+	return &assetTypeResource{}
+}
+
+func (r *assetTypeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_asset_type"
+}
+
+func (r *assetTypeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
 		Description: "Manages a Freshservice asset type",
 
-		Schema: map[string]*schema.Schema{
-			"id": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "ID of the asset type",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "ID of the asset type",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
-			"name": {
-				Type:        schema.TypeString,
+			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "Name of the asset type",
 			},
-			"description": {
-				Type:        schema.TypeString,
+			// An empty description is stored as it was by the SDKv2 resource
+			"description": schema.StringAttribute{
 				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
 				Description: "Short description of the asset type",
 			},
-			"parent_asset_type_id": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Description: "ID of the parent asset type",
+			"parent_asset_type_id": schema.Int64Attribute{
+				Optional:      true,
+				Computed:      true,
+				Description:   "ID of the parent asset type. Removing it keeps the current parent, as the API can't unset it",
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
 			},
-			"visible": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Computed:    true,
-				Description: "Visibility of the asset type. Custom asset types are set to true by default",
+			"visible": schema.BoolAttribute{
+				Optional:      true,
+				Computed:      true,
+				Description:   "Visibility of the asset type. Custom asset types are set to true by default",
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
 			},
-			"created_at": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "Creation timestamp of the asset type",
+			"created_at": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Creation timestamp of the asset type",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
-			"updated_at": {
-				Type:        schema.TypeString,
+			"updated_at": schema.StringAttribute{
 				Computed:    true,
 				Description: "Last update timestamp of the asset type",
 			},
@@ -88,195 +100,180 @@ func resourceAssetType() *schema.Resource {
 	}
 }
 
-func resourceAssetTypeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+func (r *assetTypeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.config = configureFrameworkResource(req.ProviderData, &resp.Diagnostics)
+}
 
-	// Build request body
-	assetTypeReq := AssetTypeRequest{
-		Name:        d.Get("name").(string),
-		Description: d.Get("description").(string),
+func (r *assetTypeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan assetTypeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-
-	if parentID, ok := d.GetOk("parent_asset_type_id"); ok {
-		parentAssetTypeID := parentID.(int)
-		assetTypeReq.ParentAssetTypeID = &parentAssetTypeID
+	if r.config.ReadOnly {
+		resp.Diagnostics.Append(frameworkDiagnostics(readOnlyDiagnostics("freshservice_asset_type", "create", ""))...)
+		return
 	}
 
-	// Convert request to JSON
-	jsonData, err := json.Marshal(assetTypeReq)
-	if err != nil {
-		return diag.Errorf("Failed to marshal request: %s", err)
+	// Build request body
+	assetTypeReq := &freshservice.AssetTypeRequest{
+		Name: plan.Name.ValueString(),
 	}
 
-	// Create the request
-	req, err := config.NewRequest(ctx, "POST", "/asset_types", bytes.NewReader(jsonData))
-	if err != nil {
-		return diag.FromErr(err)
+	if description := plan.Description.ValueString(); description != "" {
+		assetTypeReq.Description = &description
 	}
 
-	// Execute the request
-	resp, err := config.DoRequest(req)
-	if err != nil {
-		return diag.FromErr(err)
+	if !plan.ParentAssetTypeID.IsNull() && !plan.ParentAssetTypeID.IsUnknown() {
+		parentAssetTypeID := int(plan.ParentAssetTypeID.ValueInt64())
+		assetTypeReq.ParentAssetTypeID = &parentAssetTypeID
 	}
-	defer resp.Body.Close()
 
-	// Parse response
-	var assetTypeResp AssetTypeResponse
-	if err := json.NewDecoder(resp.Body).Decode(&assetTypeResp); err != nil {
-		return diag.Errorf("Failed to decode response: %s", err)
+	if !plan.Visible.IsNull() && !plan.Visible.IsUnknown() {
+		visible := plan.Visible.ValueBool()
+		assetTypeReq.Visible = &visible
 	}
 
-	// Set the resource ID and other computed fields
-	d.SetId(strconv.Itoa(assetTypeResp.AssetType.ID))
+	assetType, err := r.config.AssetTypes.Create(ctx, assetTypeReq)
+	if err != nil {
+		resp.Diagnostics.Append(frameworkDiagnostics(apiErrorDiagnostics(err, attributeFieldResolver(assetTypeAPIFields)))...)
+		return
+	}
 
-	return setAssetTypeData(d, &assetTypeResp.AssetType)
+	plan.set(assetType)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
-func resourceAssetTypeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
-
-	// Get the asset type ID
-	id := d.Id()
-
-	// Create the request
-	endpoint := fmt.Sprintf("/asset_types/%s", id)
-	req, err := config.NewRequest(ctx, "GET", endpoint, nil)
-	if err != nil {
-		return diag.FromErr(err)
+func (r *assetTypeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state assetTypeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Execute the request
-	resp, err := config.DoRequest(req)
+	id, err := state.intID()
 	if err != nil {
-		// If asset type not found, remove from state
-		if resp != nil && resp.StatusCode == 404 {
-			d.SetId("")
-			return nil
-		}
-		return diag.FromErr(err)
+		resp.Diagnostics.Append(frameworkDiagnostics(diag.FromErr(err))...)
+		return
 	}
-	defer resp.Body.Close()
 
-	// Parse response
-	var assetTypeResp AssetTypeResponse
-	if err := json.NewDecoder(resp.Body).Decode(&assetTypeResp); err != nil {
-		return diag.Errorf("Failed to decode response: %s", err)
+	assetType, err := r.config.AssetTypes.Get(ctx, id)
+	if errors.Is(err, freshservice.ErrNotFound) {
+		tflog.Warn(ctx, "Object no longer exists, removing it from state", map[string]interface{}{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(frameworkDiagnostics(diag.FromErr(err))...)
+		return
 	}
 
-	return setAssetTypeData(d, &assetTypeResp.AssetType)
+	state.set(assetType)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
-func resourceAssetTypeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+func (r *assetTypeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state assetTypeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if r.config.ReadOnly {
+		resp.Diagnostics.Append(frameworkDiagnostics(readOnlyDiagnostics("freshservice_asset_type", "update", state.ID.ValueString()))...)
+		return
+	}
 
 	// Get the asset type ID
-	id := d.Id()
+	id, err := state.intID()
+	if err != nil {
+		resp.Diagnostics.Append(frameworkDiagnostics(diag.FromErr(err))...)
+		return
+	}
 
 	// Build request body with only changed fields
-	assetTypeReq := AssetTypeRequest{}
+	assetTypeReq := &freshservice.AssetTypeRequest{}
 
-	if d.HasChange("name") {
-		assetTypeReq.Name = d.Get("name").(string)
+	if !plan.Name.Equal(state.Name) {
+		assetTypeReq.Name = plan.Name.ValueString()
 	}
 
-	if d.HasChange("description") {
-		assetTypeReq.Description = d.Get("description").(string)
+	// An empty description is sent too, to clear the current one
+	if !plan.Description.Equal(state.Description) {
+		description := plan.Description.ValueString()
+		assetTypeReq.Description = &description
 	}
 
-	if d.HasChange("parent_asset_type_id") {
-		if parentID, ok := d.GetOk("parent_asset_type_id"); ok {
-			parentAssetTypeID := parentID.(int)
-			assetTypeReq.ParentAssetTypeID = &parentAssetTypeID
-		}
+	if !plan.ParentAssetTypeID.Equal(state.ParentAssetTypeID) && !plan.ParentAssetTypeID.IsNull() && !plan.ParentAssetTypeID.IsUnknown() {
+		parentAssetTypeID := int(plan.ParentAssetTypeID.ValueInt64())
+		assetTypeReq.ParentAssetTypeID = &parentAssetTypeID
 	}
 
-	if d.HasChange("visible") {
-		visible := d.Get("visible").(bool)
+	if !plan.Visible.Equal(state.Visible) && !plan.Visible.IsUnknown() {
+		visible := plan.Visible.ValueBool()
 		assetTypeReq.Visible = &visible
 	}
 
-	// Convert request to JSON
-	jsonData, err := json.Marshal(assetTypeReq)
+	assetType, err := r.config.AssetTypes.Update(ctx, id, assetTypeReq)
 	if err != nil {
-		return diag.Errorf("Failed to marshal request: %s", err)
+		resp.Diagnostics.Append(frameworkDiagnostics(apiErrorDiagnostics(err, attributeFieldResolver(assetTypeAPIFields)))...)
+		return
 	}
 
-	// Create the request
-	endpoint := fmt.Sprintf("/asset_types/%s", id)
-	req, err := config.NewRequest(ctx, "PUT", endpoint, bytes.NewReader(jsonData))
-	if err != nil {
-		return diag.FromErr(err)
+	plan.set(assetType)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *assetTypeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state assetTypeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if r.config.ReadOnly {
+		resp.Diagnostics.Append(frameworkDiagnostics(readOnlyDiagnostics("freshservice_asset_type", "delete", state.ID.ValueString()))...)
+		return
 	}
 
-	// Execute the request
-	resp, err := config.DoRequest(req)
+	id, err := state.intID()
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.Append(frameworkDiagnostics(diag.FromErr(err))...)
+		return
 	}
-	defer resp.Body.Close()
 
-	// Parse response
-	var assetTypeResp AssetTypeResponse
-	if err := json.NewDecoder(resp.Body).Decode(&assetTypeResp); err != nil {
-		return diag.Errorf("Failed to decode response: %s", err)
+	// An asset type that is already gone counts as deleted
+	err = r.config.AssetTypes.Delete(ctx, id)
+	if errors.Is(err, freshservice.ErrNotFound) {
+		tflog.Debug(ctx, "Object is already deleted", map[string]interface{}{"id": state.ID.ValueString()})
+	} else if err != nil {
+		resp.Diagnostics.Append(frameworkDiagnostics(diag.FromErr(err))...)
 	}
-
-	return setAssetTypeData(d, &assetTypeResp.AssetType)
 }
 
-func resourceAssetTypeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
-
-	// Get the asset type ID
-	id := d.Id()
-
-	// Create the request
-	endpoint := fmt.Sprintf("/asset_types/%s", id)
-	req, err := config.NewRequest(ctx, "DELETE", endpoint, nil)
-	if err != nil {
-		return diag.FromErr(err)
-	}
+func (r *assetTypeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
 
-	// Execute the request
-	resp, err := config.DoRequest(req)
+// intID returns the numeric ID of the asset type
+func (m *assetTypeResourceModel) intID() (int, error) {
+	id, err := strconv.Atoi(m.ID.ValueString())
 	if err != nil {
-		// If asset type not found, it's already deleted
-		if resp != nil && resp.StatusCode == 404 {
-			return nil
-		}
-		return diag.FromErr(err)
+		return 0, fmt.Errorf("invalid ID %q, expected a number", m.ID.ValueString())
 	}
-	defer resp.Body.Close()
-
-	// Clear the resource ID
-	d.SetId("")
-
-	return nil
+	return id, nil
 }
 
-// setAssetTypeData sets the asset type data in the Terraform state
-func setAssetTypeData(d *schema.ResourceData, assetType *AssetType) diag.Diagnostics {
-	if err := d.Set("name", assetType.Name); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("description", assetType.Description); err != nil {
-		return diag.FromErr(err)
-	}
+// set sets the asset type data in the model
+func (m *assetTypeResourceModel) set(assetType *freshservice.AssetType) {
+	m.ID = types.StringValue(strconv.Itoa(assetType.ID))
+	m.Name = types.StringValue(assetType.Name)
+	m.Description = types.StringValue(assetType.Description)
 	if assetType.ParentAssetTypeID != nil {
-		if err := d.Set("parent_asset_type_id", *assetType.ParentAssetTypeID); err != nil {
-			return diag.FromErr(err)
-		}
-	}
-	if err := d.Set("visible", assetType.Visible); err != nil {
-		return diag.FromErr(err)
+		m.ParentAssetTypeID = types.Int64Value(int64(*assetType.ParentAssetTypeID))
+	} else {
+		m.ParentAssetTypeID = types.Int64Null()
 	}
-	if err := d.Set("created_at", assetType.CreatedAt.Format(time.RFC3339)); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("updated_at", assetType.UpdatedAt.Format(time.RFC3339)); err != nil {
-		return diag.FromErr(err)
-	}
-
-	return nil
+	m.Visible = types.BoolValue(assetType.Visible)
+	m.CreatedAt = types.StringValue(assetType.CreatedAt.Format(time.RFC3339))
+	m.UpdatedAt = types.StringValue(assetType.UpdatedAt.Format(time.RFC3339))
 }
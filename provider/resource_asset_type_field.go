@@ -0,0 +1,354 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lcp-llp/terraform-provider-freshservice/freshservice"
+)
+
+// assetTypeFieldAPIFields maps asset type field properties reported in API errors to resource attributes
+var assetTypeFieldAPIFields = map[string]string{
+	"label":     "label",
+	"desc":      "description",
+	"data_type": "field_type",
+	"mandatory": "required",
+	"choices":   "choices",
+}
+
+func resourceAssetTypeField() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAssetTypeFieldCreate,
+		ReadContext:   resourceAssetTypeFieldRead,
+		UpdateContext: resourceAssetTypeFieldUpdate,
+		DeleteContext: resourceAssetTypeFieldDelete,
+		CustomizeDiff: resourceAssetTypeFieldCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceAssetTypeFieldImport,
+		},
+		Description: "Manages a custom field of a Freshservice asset type",
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the field in the form <asset_type_id>/<field_id>",
+			},
+			"asset_type_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the asset type the field belongs to",
+			},
+			"label": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Label of the field shown in Freshservice",
+			},
+			"field_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true, // Freshservice cannot convert existing values to another type
+				ValidateFunc: validation.StringInSlice([]string{
+					freshservice.FieldTypeText, freshservice.FieldTypeParagraph, freshservice.FieldTypeNumber, freshservice.FieldTypeDecimal,
+					freshservice.FieldTypeDate, freshservice.FieldTypeDropdown, freshservice.FieldTypeCheckbox,
+				}, false),
+				Description: "Data type of the field: text, paragraph, number, decimal, date, dropdown or checkbox",
+			},
+			"required": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether a value is required for assets of this type (default: false)",
+			},
+			"choices": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Allowed values of a dropdown field, in display order",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the field",
+			},
+			// Computed fields
+			"field_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the field",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the field as used in type_fields, without the asset type ID suffix (e.g. 'account_id')",
+			},
+			"full_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the field as sent to the API, including the asset type ID suffix (e.g. 'account_id_56000947175')",
+			},
+		},
+	}
+}
+
+// resourceAssetTypeFieldCustomizeDiff checks that choices are only used with dropdown fields
+func resourceAssetTypeFieldCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("field_type") || !d.NewValueKnown("choices") {
+		return nil
+	}
+
+	fieldType := d.Get("field_type").(string)
+	choices := d.Get("choices").([]interface{})
+
+	if fieldType == freshservice.FieldTypeDropdown && len(choices) == 0 {
+		return fmt.Errorf("choices: at least one choice is required for dropdown fields")
+	}
+	if fieldType != freshservice.FieldTypeDropdown && len(choices) > 0 {
+		return fmt.Errorf("choices: only dropdown fields can have choices, field_type is %q", fieldType)
+	}
+
+	return nil
+}
+
+func resourceAssetTypeFieldCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	assetTypeID := d.Get("asset_type_id").(int)
+	fieldReq := expandAssetTypeFieldRequest(d)
+
+	field, err := config.AssetTypes.CreateField(ctx, assetTypeID, fieldReq)
+	if err != nil {
+		return apiErrorDiagnostics(err, attributeFieldResolver(assetTypeFieldAPIFields))
+	}
+
+	// The cached definitions of the asset type are now stale
+	config.invalidateAssetTypeFields(assetTypeID)
+
+	if field.ID == 0 {
+		// Fall back to finding the new field by its label when the response has no ID
+		field, err = findAssetTypeField(ctx, config, assetTypeID, func(f *freshservice.AssetTypeField) bool {
+			return f.Label == fieldReq.Label
+		})
+		if err != nil {
+			return diag.Errorf("Field %q was created but its ID could not be determined: %s. Import the new field by <asset_type_id>/<field_id>", fieldReq.Label, err)
+		}
+		if field == nil {
+			return diag.Errorf("Field %q was created but could not be found on asset type %d", fieldReq.Label, assetTypeID)
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%d/%d", assetTypeID, field.ID))
+
+	return resourceAssetTypeFieldRead(ctx, d, meta)
+}
+
+func resourceAssetTypeFieldRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	assetTypeID, fieldID, err := parseAssetTypeFieldID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	field, err := findAssetTypeField(ctx, config, assetTypeID, func(f *freshservice.AssetTypeField) bool {
+		return f.ID == fieldID
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// If the field or its asset type is gone, remove from state
+	if field == nil {
+		d.SetId("")
+		return nil
+	}
+
+	return setAssetTypeFieldData(d, assetTypeID, field)
+}
+
+func resourceAssetTypeFieldUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	assetTypeID, fieldID, err := parseAssetTypeFieldID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The data type cannot change, so it is left out of updates
+	fieldReq := expandAssetTypeFieldRequest(d)
+	fieldReq.DataType = ""
+
+	if err := config.AssetTypes.UpdateField(ctx, assetTypeID, fieldID, fieldReq); err != nil {
+		return apiErrorDiagnostics(err, attributeFieldResolver(assetTypeFieldAPIFields))
+	}
+
+	config.invalidateAssetTypeFields(assetTypeID)
+
+	return resourceAssetTypeFieldRead(ctx, d, meta)
+}
+
+func resourceAssetTypeFieldDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	assetTypeID, fieldID, err := parseAssetTypeFieldID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// A 404 means the field is already gone
+	err = config.AssetTypes.DeleteField(ctx, assetTypeID, fieldID)
+	if err != nil && !errors.Is(err, freshservice.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+
+	config.invalidateAssetTypeFields(assetTypeID)
+
+	d.SetId("")
+
+	return nil
+}
+
+// resourceAssetTypeFieldImport imports a field by <asset_type_id>/<field_id> or <asset_type_id>/<name>
+func resourceAssetTypeFieldImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*Config)
+
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid import ID %q, expected <asset_type_id>/<field_id> or <asset_type_id>/<name>", d.Id())
+	}
+	assetTypeID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid asset type ID %q in import ID", parts[0])
+	}
+
+	// Numeric IDs are used as is, anything else is looked up by field name
+	if _, err := strconv.Atoi(parts[1]); err == nil {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	field, err := findAssetTypeField(ctx, config, assetTypeID, func(f *freshservice.AssetTypeField) bool {
+		return f.Name == parts[1] || f.ShortName() == parts[1]
+	})
+	if err != nil {
+		return nil, err
+	}
+	if field == nil {
+		return nil, fmt.Errorf("no field named %q found on asset type %d", parts[1], assetTypeID)
+	}
+
+	d.SetId(fmt.Sprintf("%d/%d", assetTypeID, field.ID))
+	return []*schema.ResourceData{d}, nil
+}
+
+// expandAssetTypeFieldRequest builds the request body from the resource data
+func expandAssetTypeFieldRequest(d *schema.ResourceData) *freshservice.AssetTypeFieldRequest {
+	fieldReq := &freshservice.AssetTypeFieldRequest{
+		Label:       d.Get("label").(string),
+		Description: d.Get("description").(string),
+		DataType:    d.Get("field_type").(string),
+		Mandatory:   d.Get("required").(bool),
+	}
+
+	for _, choice := range d.Get("choices").([]interface{}) {
+		value, _ := choice.(string)
+		fieldReq.Choices = append(fieldReq.Choices, value)
+	}
+
+	return fieldReq
+}
+
+// findAssetTypeField fetches the current fields of an asset type and returns the one
+// matching the predicate. It returns nil without an error when there is no match or the
+// asset type does not exist, and an error when several fields match.
+func findAssetTypeField(ctx context.Context, config *Config, assetTypeID int, match func(*freshservice.AssetTypeField) bool) (*freshservice.AssetTypeField, error) {
+	config.invalidateAssetTypeFields(assetTypeID)
+
+	fields, err := config.getAssetTypeFields(ctx, assetTypeID)
+	if err != nil {
+		if errors.Is(err, freshservice.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var matches []*freshservice.AssetTypeField
+	for i := range fields.Fields {
+		if match(&fields.Fields[i]) {
+			matches = append(matches, &fields.Fields[i])
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		return matches[0], nil
+	}
+
+	names := make([]string, len(matches))
+	for i, field := range matches {
+		names[i] = fmt.Sprintf("%s (ID %d)", field.Name, field.ID)
+	}
+	return nil, fmt.Errorf("%d fields of asset type %d match: %s", len(matches), assetTypeID, strings.Join(names, ", "))
+}
+
+// parseAssetTypeFieldID splits a resource ID into the asset type ID and field ID
+func parseAssetTypeFieldID(id string) (int, int, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid ID %q, expected <asset_type_id>/<field_id>", id)
+	}
+
+	assetTypeID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid asset type ID in %q: %w", id, err)
+	}
+	fieldID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid field ID in %q: %w", id, err)
+	}
+
+	return assetTypeID, fieldID, nil
+}
+
+// setAssetTypeFieldData sets the asset type field data in the Terraform state
+func setAssetTypeFieldData(d *schema.ResourceData, assetTypeID int, field *freshservice.AssetTypeField) diag.Diagnostics {
+	if err := d.Set("asset_type_id", assetTypeID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("field_id", field.ID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("label", field.Label); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("field_type", field.DataType); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("required", field.Mandatory); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("choices", field.ChoiceValues()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("description", field.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", field.ShortName()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("full_name", field.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResourceAssetTypeField_lifecycle(t *testing.T) {
+	h := newTestHarness(t)
+
+	assetType := h.apply("freshservice_asset_type", nil, map[string]interface{}{"name": "Cloud Account"})
+
+	config := map[string]interface{}{
+		"asset_type_id": assetType.ID,
+		"label":         "Environment",
+		"field_type":    "dropdown",
+		"choices":       []interface{}{"Production", "Staging"},
+		"required":      true,
+	}
+
+	// Create
+	state := h.apply("freshservice_asset_type_field", nil, config)
+	requireAttributes(t, state, map[string]string{
+		"label":     "Environment",
+		"name":      "environment",
+		"full_name": "environment_" + assetType.ID,
+		"required":  "true",
+		"choices.#": "2",
+		"choices.1": "Staging",
+	})
+	if !strings.HasPrefix(state.ID, assetType.ID+"/") {
+		t.Errorf("unexpected ID %q", state.ID)
+	}
+
+	// Read
+	state = h.refresh("freshservice_asset_type_field", state)
+	h.requireNoPlan("freshservice_asset_type_field", state, config)
+
+	// The new field can be used by assets right away
+	asset := h.apply("freshservice_cloud_asset", nil, map[string]interface{}{
+		"name":          "Production account",
+		"asset_type_id": assetType.ID,
+		"type_fields":   map[string]interface{}{"environment": "Production"},
+	})
+	requireAttributes(t, asset, map[string]string{"type_fields.environment": "Production"})
+
+	// Update
+	config["label"] = "Deployment Environment"
+	config["choices"] = []interface{}{"Production", "Staging", "Development"}
+	state = h.apply("freshservice_asset_type_field", state, config)
+	requireAttributes(t, state, map[string]string{
+		"label":     "Deployment Environment",
+		"choices.#": "3",
+		"name":      "environment",
+	})
+	h.requireNoPlan("freshservice_asset_type_field", h.refresh("freshservice_asset_type_field", state), config)
+
+	// Import by ID and by name
+	for _, id := range []string{state.ID, assetType.ID + "/environment"} {
+		imported := h.importState("freshservice_asset_type_field", id)
+		requireAttributes(t, imported, map[string]string{
+			"id":         state.ID,
+			"label":      "Deployment Environment",
+			"field_type": "dropdown",
+		})
+	}
+
+	// Delete
+	h.destroy("freshservice_asset_type_field", state)
+	if h.refresh("freshservice_asset_type_field", state) != nil {
+		t.Error("expected the deleted field to be removed from state")
+	}
+}
+
+func TestResourceAssetTypeField_choicesValidation(t *testing.T) {
+	h := newTestHarness(t)
+
+	for fieldType, choices := range map[string][]interface{}{
+		"text":     {"a"},
+		"dropdown": {},
+	} {
+		_, err := h.plan("freshservice_asset_type_field", nil, map[string]interface{}{
+			"asset_type_id": testHardwareTypeID,
+			"label":         "Tier",
+			"field_type":    fieldType,
+			"choices":       choices,
+		})
+		if err == nil || !strings.Contains(err.Error(), "choices") {
+			t.Errorf("%s: expected a choices error, got %v", fieldType, err)
+		}
+	}
+}
+
+func TestResourceAssetTypeField_createWithoutID(t *testing.T) {
+	h := newTestHarness(t)
+	h.fake.OmitFieldIDs()
+
+	config := map[string]interface{}{
+		"asset_type_id": testHardwareTypeID,
+		"label":         "Rack",
+		"field_type":    "text",
+	}
+
+	// The new field is found by its label
+	state := h.apply("freshservice_asset_type_field", nil, config)
+	requireAttributes(t, state, map[string]string{"label": "Rack", "name": "rack"})
+
+	// Creating fails rather than picking one of several fields with the label
+	h.fake.AddField(testHardwareTypeID, "rack_location", "Rack Position", "text")
+	config["label"] = "Rack Position"
+	h.fake.AddField(testHardwareTypeID, "rack_position_old", "Rack Position", "text")
+	_, err := h.tryApply("freshservice_asset_type_field", nil, config)
+	if err == nil || !strings.Contains(err.Error(), "fields of asset type") {
+		t.Fatalf("expected an error about several matching fields, got %v", err)
+	}
+}
+
+func TestResourceAssetTypeField_assetTypeDeleted(t *testing.T) {
+	h := newTestHarness(t)
+
+	state := h.apply("freshservice_asset_type_field", nil, map[string]interface{}{
+		"asset_type_id": testHardwareTypeID,
+		"label":         "Rack",
+		"field_type":    "text",
+	})
+
+	h.fake.DeleteAssetType(testHardwareTypeID)
+
+	if h.refresh("freshservice_asset_type_field", state) != nil {
+		t.Fatalf("expected field %s to be removed from state", state.ID)
+	}
+}
@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestResourceAssetType_lifecycle(t *testing.T) {
+	h := newTestHarness(t)
+
+	config := map[string]interface{}{
+		"name":        "Cloud Account",
+		"description": "Accounts in public clouds",
+	}
+
+	// Create
+	state := h.apply("freshservice_asset_type", nil, config)
+	requireAttributes(t, state, map[string]string{
+		"name":        "Cloud Account",
+		"description": "Accounts in public clouds",
+		"visible":     "true",
+	})
+
+	// Read
+	state = h.refresh("freshservice_asset_type", state)
+	h.requireNoPlan("freshservice_asset_type", state, config)
+
+	// Update
+	config["description"] = "Accounts in AWS, Azure and GCP"
+	config["parent_asset_type_id"] = testHardwareTypeID
+	state = h.apply("freshservice_asset_type", state, config)
+	requireAttributes(t, state, map[string]string{
+		"description":          "Accounts in AWS, Azure and GCP",
+		"parent_asset_type_id": strconv.Itoa(testHardwareTypeID),
+	})
+	h.requireNoPlan("freshservice_asset_type", h.refresh("freshservice_asset_type", state), config)
+
+	// Import
+	imported := h.importState("freshservice_asset_type", state.ID)
+	requireAttributes(t, imported, map[string]string{
+		"name":        "Cloud Account",
+		"description": "Accounts in AWS, Azure and GCP",
+	})
+
+	// Duplicate names are reported on the name attribute
+	_, err := h.tryApply("freshservice_asset_type", nil, map[string]interface{}{"name": "cloud account"})
+	if err == nil || !strings.Contains(err.Error(), "name: ") {
+		t.Fatalf("expected a duplicate name error on name, got %v", err)
+	}
+
+	// Delete
+	h.destroy("freshservice_asset_type", state)
+	if n := h.fake.CountRequests("DELETE /api/v2/asset_types/" + state.ID); n != 1 {
+		t.Errorf("expected 1 delete request, got %d", n)
+	}
+}
+
+func TestResourceAssetType_deletedOutsideTerraform(t *testing.T) {
+	h := newTestHarness(t)
+
+	state := h.apply("freshservice_asset_type", nil, map[string]interface{}{"name": "Printer"})
+
+	id, _ := strconv.Atoi(state.ID)
+	h.fake.DeleteAssetType(id)
+
+	if h.refresh("freshservice_asset_type", state) != nil {
+		t.Fatal("expected the deleted asset type to be removed from state")
+	}
+}
+
+func TestResourceAssetType_clearDescription(t *testing.T) {
+	h := newTestHarness(t)
+
+	config := map[string]interface{}{"name": "Printer", "description": "Office printers"}
+	state := h.apply("freshservice_asset_type", nil, config)
+
+	// Removing the description clears it in Freshservice, not just in state
+	delete(config, "description")
+	state = h.apply("freshservice_asset_type", state, config)
+	requireAttributes(t, state, map[string]string{"description": ""})
+
+	id, _ := strconv.Atoi(state.ID)
+	if description := h.fake.AssetType(id)["description"]; description != "" {
+		t.Errorf("expected the description to be cleared, got %q", description)
+	}
+	h.requireNoPlan("freshservice_asset_type", h.refresh("freshservice_asset_type", state), config)
+}
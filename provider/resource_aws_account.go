@@ -1,52 +1,43 @@
 package provider
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"log"
+	"regexp"
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lcp-llp/terraform-provider-freshservice/freshservice"
 )
 
-// AWSAccountAsset represents a Freshservice AWS Account asset
-type AWSAccountAsset struct {
-	ID           int                    `json:"id"`
-	DisplayID    int                    `json:"display_id"`
-	Name         string                 `json:"name"`
-	Description  string                 `json:"description"`
-	AssetTypeID  int                    `json:"asset_type_id"`
-	Impact       string                 `json:"impact"`
-	AuthorType   string                 `json:"author_type"`
-	UsageType    string                 `json:"usage_type"`
-	AssetTag     string                 `json:"asset_tag"`
-	UserID       *int                   `json:"user_id"`
-	LocationID   *int                   `json:"location_id"`
-	DepartmentID *int                   `json:"department_id"`
-	AgentID      *int                   `json:"agent_id"`
-	AssignedOn   *string                `json:"assigned_on"`
-	CreatedAt    time.Time              `json:"created_at"`
-	UpdatedAt    time.Time              `json:"updated_at"`
-	WorkspaceID  int                    `json:"workspace_id"`
-	TypeFields   map[string]interface{} `json:"type_fields"`
-}
+// defaultAWSAccountTypeID is the ID of the AWS account asset type the resource manages unless asset_type_id is set
+const defaultAWSAccountTypeID = 56000947175
 
-// AWSAccountAssetResponse represents the API response for AWS account asset operations
-type AWSAccountAssetResponse struct {
-	Asset AWSAccountAsset `json:"asset"`
+// awsAccountTypeFields maps AWS account type fields (without the asset type ID suffix) to resource attributes
+var awsAccountTypeFields = map[string]string{
+	"account_id":  "account_id",
+	"po":          "po_number",
+	"owner":       "owner",
+	"approved_by": "approver",
+	"environment": "environment",
 }
 
-// AWSAccountAssetRequest represents the request body for AWS account asset operations
-type AWSAccountAssetRequest struct {
-	Name        string                 `json:"name"`
-	AssetTypeID int                    `json:"asset_type_id"`
-	Description string                 `json:"description,omitempty"`
-	TypeFields  map[string]interface{} `json:"type_fields"`
+// awsAccountIDPattern matches AWS account IDs
+var awsAccountIDPattern = regexp.MustCompile(`^\d{12}$`)
+
+// awsAccountFieldResolver maps fields reported in API errors to AWS account attributes
+func awsAccountFieldResolver(assetTypeID int) apiFieldResolver {
+	return assetFieldResolver(assetTypeID, map[string]string{
+		"name":          "account_name",
+		"description":   "description",
+		"asset_type_id": "asset_type_id",
+		"workspace_id":  "workspace_id",
+	}, awsAccountTypeFields)
 }
 
 func resourceAWSAccount() *schema.Resource {
@@ -55,8 +46,9 @@ func resourceAWSAccount() *schema.Resource {
 		ReadContext:   resourceAWSAccountRead,
 		UpdateContext: resourceAWSAccountUpdate,
 		DeleteContext: resourceAWSAccountDelete,
+		CustomizeDiff: customdiff.All(customizeAWSAccountIDField, attributeTypeFieldsAllCustomizeDiff(awsAccountTypeFields), customizeTrashedAsset),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importAssetState(defaultAWSAccountTypeID, "account_id"),
 		},
 		Description: "Manages a Freshservice AWS Account asset",
 
@@ -72,9 +64,10 @@ func resourceAWSAccount() *schema.Resource {
 				Description: "Name of the AWS account",
 			},
 			"account_id": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "AWS account ID",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringMatch(awsAccountIDPattern, "must be a 12-digit AWS account ID, including any leading zeros"),
+				Description:  "AWS account ID, as the 12 digits including any leading zeros",
 			},
 			"po_number": {
 				Type:        schema.TypeString,
@@ -104,9 +97,14 @@ func resourceAWSAccount() *schema.Resource {
 			"asset_type_id": {
 				Type:        schema.TypeInt,
 				Optional:    true,
-				Default:     int64(56000947175),
-				Description: "Asset type ID for AWS account (default: 56000947175)",
+				Default:     int64(defaultAWSAccountTypeID),
+				Description: fmt.Sprintf("Asset type ID for AWS account (default: %d)", defaultAWSAccountTypeID),
 			},
+			"delete_mode":        assetDeleteModeSchema(),
+			"adopt_existing":     assetAdoptExistingSchema("account_id"),
+			"restore_if_trashed": assetRestoreIfTrashedSchema(),
+			"trashed":            assetTrashedSchema(),
+			"type_fields_all":    typeFieldsAllSchema(),
 			// Computed fields
 			"display_id": {
 				Type:        schema.TypeInt,
@@ -129,296 +127,136 @@ func resourceAWSAccount() *schema.Resource {
 				Description: "Last update timestamp of the asset",
 			},
 			"workspace_id": {
-				Type:        schema.TypeInt,
-				Computed:    true,
-				Description: "Workspace ID of the asset",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Workspace ID of the asset. Defaults to the provider's default_workspace_id, or the default workspace of the account. Changing this moves the asset to the new workspace",
 			},
 		},
 	}
 }
 
-func resourceAWSAccountCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
-
-	// Get asset type ID
-	assetTypeID := d.Get("asset_type_id").(int)
-
-	log.Printf("[DEBUG] Creating AWS account asset with type ID: %d", assetTypeID)
-
-	// Build type_fields with the specific field names based on asset type ID
-	typeFields := map[string]interface{}{}
-
-	if accountID := d.Get("account_id").(string); accountID != "" {
-		// Convert to integer to match Python script behavior
-		if accountIDInt, err := strconv.Atoi(accountID); err == nil {
-			typeFields[fmt.Sprintf("account_id_%d", assetTypeID)] = accountIDInt
-			log.Printf("[DEBUG] Added account_id_%d: %d (converted from string)", assetTypeID, accountIDInt)
-		} else {
-			// Fallback to string if conversion fails
-			typeFields[fmt.Sprintf("account_id_%d", assetTypeID)] = accountID
-			log.Printf("[DEBUG] Added account_id_%d: %s (as string)", assetTypeID, accountID)
-		}
+// customizeAWSAccountIDField checks at plan time that the account_id field of the asset type
+// holds text, as a number field drops the leading zeros of account IDs
+func customizeAWSAccountIDField(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("asset_type_id") {
+		return nil
 	}
 
-	if poNumber := d.Get("po_number").(string); poNumber != "" {
-		typeFields[fmt.Sprintf("po_%d", assetTypeID)] = poNumber
-		log.Printf("[DEBUG] Added po_%d: %s", assetTypeID, poNumber)
+	config := meta.(*Config)
+	fields, err := config.getAssetTypeFields(ctx, d.Get("asset_type_id").(int))
+	if err != nil {
+		return err
 	}
 
-	if owner := d.Get("owner").(string); owner != "" {
-		typeFields[fmt.Sprintf("owner_%d", assetTypeID)] = owner
-		log.Printf("[DEBUG] Added owner_%d: %s", assetTypeID, owner)
+	// A missing field is reported by the API when the asset is written
+	field, ok := fields.Lookup("account_id")
+	if !ok || field.DataType == freshservice.FieldTypeText || field.DataType == freshservice.FieldTypeParagraph {
+		return nil
 	}
+	return fmt.Errorf("account_id: field %s of asset type %d is a %s field, which would drop the leading zeros of AWS account IDs. Change it to a text field in Freshservice, or set asset_type_id to an asset type whose account_id field is text",
+		field.Name, fields.AssetTypeID, field.DataType)
+}
 
-	if approver := d.Get("approver").(string); approver != "" {
-		typeFields[fmt.Sprintf("approved_by_%d", assetTypeID)] = approver
-		log.Printf("[DEBUG] Added approved_by_%d: %s", assetTypeID, approver)
-	}
+func resourceAWSAccountCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	// Get asset type ID
+	assetTypeID := d.Get("asset_type_id").(int)
 
-	if environment := d.Get("environment").(string); environment != "" {
-		typeFields[fmt.Sprintf("environment_%d", assetTypeID)] = environment
-		log.Printf("[DEBUG] Added environment_%d: %s", assetTypeID, environment)
+	// Take over an existing asset with the same account ID instead of creating a duplicate
+	existing, diags := adoptAssetByTypeField(ctx, d, config, assetTypeID, "account_id")
+	if diags.HasError() {
+		return diags
+	}
+	if existing != nil {
+		d.SetId(strconv.Itoa(existing.DisplayID))
+		return resourceAWSAccountUpdate(ctx, d, meta)
 	}
 
-	log.Printf("[DEBUG] Final type_fields: %+v", typeFields)
+	tflog.Debug(ctx, "Creating AWS account asset", map[string]interface{}{"asset_type_id": assetTypeID})
 
 	// Build request body
-	assetReq := AWSAccountAssetRequest{
+	assetReq := &freshservice.AssetRequest{
 		Name:        d.Get("account_name").(string),
 		AssetTypeID: assetTypeID,
-		Description: d.Get("description").(string),
-		TypeFields:  typeFields,
-	}
-
-	log.Printf("[DEBUG] Asset request payload: Name=%s, AssetTypeID=%d, Description=%s",
-		assetReq.Name, assetReq.AssetTypeID, assetReq.Description)
-
-	// Convert request to JSON
-	jsonData, err := json.Marshal(assetReq)
-	if err != nil {
-		return diag.Errorf("Failed to marshal request: %s", err)
+		Description: config.Defaults.stampDescription(d.Get("description").(string)),
+		WorkspaceID: assetWorkspaceID(d, config),
+		TypeFields:  attributeTypeFields(d, assetTypeID, awsAccountTypeFields),
 	}
 
-	log.Printf("[DEBUG] Request JSON: %s", string(jsonData))
+	config.Defaults.applyTypeFields(assetReq.TypeFields, assetTypeID, awsAccountTypeFields)
 
-	// Create the request
-	req, err := config.NewRequest(ctx, "POST", "/assets", bytes.NewReader(jsonData))
+	asset, err := config.Assets.Create(ctx, assetReq)
 	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	log.Printf("[DEBUG] Making API request to: %s", req.URL.String())
-	log.Printf("[DEBUG] Request headers: %+v", req.Header)
-
-	// Execute the request
-	resp, err := config.DoRequest(req)
-	if err != nil {
-		log.Printf("[ERROR] API request failed: %s", err)
-		return diag.FromErr(err)
-	}
-	defer resp.Body.Close()
-
-	log.Printf("[DEBUG] API response status: %d %s", resp.StatusCode, resp.Status)
-	log.Printf("[DEBUG] Response headers: %+v", resp.Header)
-
-	// Read response body for logging before decoding
-	respBodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		log.Printf("[ERROR] Failed to read response body: %s", err)
-		return diag.Errorf("Failed to read response body: %s", err)
-	}
-
-	log.Printf("[DEBUG] Response body: %s", string(respBodyBytes))
-
-	// Parse response
-	var assetResp AWSAccountAssetResponse
-	if err := json.Unmarshal(respBodyBytes, &assetResp); err != nil {
-		log.Printf("[ERROR] Failed to decode response: %s", err)
-		log.Printf("[ERROR] Response body was: %s", string(respBodyBytes))
-		return diag.Errorf("Failed to decode response: %s", err)
+		return apiErrorDiagnostics(err, awsAccountFieldResolver(assetTypeID))
 	}
 
-	log.Printf("[DEBUG] Successfully created asset with ID: %d", assetResp.Asset.DisplayID)
-
 	// Set the resource ID using display_id (which is used for API calls) and other computed fields
-	d.SetId(strconv.Itoa(assetResp.Asset.DisplayID))
+	d.SetId(strconv.Itoa(asset.DisplayID))
 
-	return setAWSAccountAssetData(d, &assetResp.Asset)
+	return setAWSAccountAssetData(d, config, asset)
 }
 
 func resourceAWSAccountRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
 
-	// Get the asset display ID (stored as Terraform resource ID)
-	displayID := d.Id()
-
-	// Create the request using display_id
-	endpoint := fmt.Sprintf("/assets/%s", displayID)
-	req, err := config.NewRequest(ctx, "GET", endpoint, nil)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	// Execute the request
-	resp, err := config.DoRequest(req)
-	if err != nil {
-		// If asset not found, remove from state
-		if resp != nil && resp.StatusCode == 404 {
-			d.SetId("")
-			return nil
-		}
-		return diag.FromErr(err)
-	}
-	defer resp.Body.Close()
-
-	// Parse response
-	var assetResp AWSAccountAssetResponse
-	if err := json.NewDecoder(resp.Body).Decode(&assetResp); err != nil {
-		return diag.Errorf("Failed to decode response: %s", err)
+	asset, diags := readAssetResource(ctx, d, config)
+	if asset == nil {
+		return diags
 	}
 
-	return setAWSAccountAssetData(d, &assetResp.Asset)
+	return setAWSAccountAssetData(d, config, asset)
 }
 
 func resourceAWSAccountUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
 
+	if update, diags := prepareAssetUpdate(ctx, d, config); !update {
+		return diags
+	}
+
 	// Get the asset display ID (stored as Terraform resource ID)
-	displayID := d.Id()
+	displayID, err := resourceIntID(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	// Get asset type ID
 	assetTypeID := d.Get("asset_type_id").(int)
 
-	log.Printf("[DEBUG] Updating AWS account asset %s with type ID: %d", displayID, assetTypeID)
+	tflog.Debug(ctx, "Updating AWS account asset", map[string]interface{}{"display_id": displayID, "asset_type_id": assetTypeID})
 
 	// Build request body with all current values (not just changed fields)
-	assetReq := AWSAccountAssetRequest{
+	assetReq := &freshservice.AssetRequest{
 		Name:        d.Get("account_name").(string),
 		AssetTypeID: assetTypeID,
-		Description: d.Get("description").(string),
-		TypeFields:  map[string]interface{}{},
+		Description: config.Defaults.stampDescription(d.Get("description").(string)),
+		TypeFields:  attributeTypeFields(d, assetTypeID, awsAccountTypeFields),
 	}
 
-	// Always include all type_fields (not just changed ones)
-	if accountID := d.Get("account_id").(string); accountID != "" {
-		// Convert to integer to match Python script behavior
-		if accountIDInt, err := strconv.Atoi(accountID); err == nil {
-			assetReq.TypeFields[fmt.Sprintf("account_id_%d", assetTypeID)] = accountIDInt
-			log.Printf("[DEBUG] Added account_id_%d: %d (converted from string)", assetTypeID, accountIDInt)
-		} else {
-			// Fallback to string if conversion fails
-			assetReq.TypeFields[fmt.Sprintf("account_id_%d", assetTypeID)] = accountID
-			log.Printf("[DEBUG] Added account_id_%d: %s (as string)", assetTypeID, accountID)
-		}
-	}
-
-	if poNumber := d.Get("po_number").(string); poNumber != "" {
-		assetReq.TypeFields[fmt.Sprintf("po_%d", assetTypeID)] = poNumber
-		log.Printf("[DEBUG] Added po_%d: %s", assetTypeID, poNumber)
-	}
-
-	if owner := d.Get("owner").(string); owner != "" {
-		assetReq.TypeFields[fmt.Sprintf("owner_%d", assetTypeID)] = owner
-		log.Printf("[DEBUG] Added owner_%d: %s", assetTypeID, owner)
-	}
-
-	if approver := d.Get("approver").(string); approver != "" {
-		assetReq.TypeFields[fmt.Sprintf("approved_by_%d", assetTypeID)] = approver
-		log.Printf("[DEBUG] Added approved_by_%d: %s", assetTypeID, approver)
-	}
-
-	if environment := d.Get("environment").(string); environment != "" {
-		assetReq.TypeFields[fmt.Sprintf("environment_%d", assetTypeID)] = environment
-		log.Printf("[DEBUG] Added environment_%d: %s", assetTypeID, environment)
-	}
+	config.Defaults.applyTypeFields(assetReq.TypeFields, assetTypeID, awsAccountTypeFields)
 
-	log.Printf("[DEBUG] Final type_fields for update: %+v", assetReq.TypeFields)
-
-	// Convert request to JSON
-	jsonData, err := json.Marshal(assetReq)
-	if err != nil {
-		return diag.Errorf("Failed to marshal request: %s", err)
-	}
-
-	log.Printf("[DEBUG] Update request JSON: %s", string(jsonData))
-
-	// Create the request
-	endpoint := fmt.Sprintf("/assets/%s", displayID)
-	req, err := config.NewRequest(ctx, "PUT", endpoint, bytes.NewReader(jsonData))
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	log.Printf("[DEBUG] Making update API request to: %s", req.URL.String())
-
-	// Execute the request
-	resp, err := config.DoRequest(req)
+	asset, err := config.Assets.Update(ctx, displayID, assetReq)
 	if err != nil {
-		log.Printf("[ERROR] Update API request failed: %s", err)
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(err, awsAccountFieldResolver(assetTypeID))
 	}
-	defer resp.Body.Close()
-
-	log.Printf("[DEBUG] Update API response status: %d %s", resp.StatusCode, resp.Status)
 
-	// Read response body for logging before decoding
-	respBodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		log.Printf("[ERROR] Failed to read update response body: %s", err)
-		return diag.Errorf("Failed to read response body: %s", err)
-	}
-
-	log.Printf("[DEBUG] Update response body: %s", string(respBodyBytes))
-
-	// Parse response
-	var assetResp AWSAccountAssetResponse
-	if err := json.Unmarshal(respBodyBytes, &assetResp); err != nil {
-		log.Printf("[ERROR] Failed to decode update response: %s", err)
-		log.Printf("[ERROR] Response body was: %s", string(respBodyBytes))
-		return diag.Errorf("Failed to decode response: %s", err)
-	}
-
-	log.Printf("[DEBUG] Successfully updated asset with ID: %d", assetResp.Asset.DisplayID)
-
-	return setAWSAccountAssetData(d, &assetResp.Asset)
+	return setAWSAccountAssetData(d, config, asset)
 }
 
 func resourceAWSAccountDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-
-	// Get the asset display ID (stored as Terraform resource ID)
-	displayID := d.Id()
-
-	// Create the request
-	endpoint := fmt.Sprintf("/assets/%s", displayID)
-	req, err := config.NewRequest(ctx, "DELETE", endpoint, nil)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	// Execute the request
-	resp, err := config.DoRequest(req)
-	if err != nil {
-		// If asset not found, it's already deleted
-		if resp != nil && resp.StatusCode == 404 {
-			return nil
-		}
-		return diag.FromErr(err)
-	}
-	defer resp.Body.Close()
-
-	// Clear the resource ID
-	d.SetId("")
-
-	return nil
+	return deleteAssetResource(ctx, d, config)
 }
 
 // setAWSAccountAssetData sets the AWS account asset data in the Terraform state
-func setAWSAccountAssetData(d *schema.ResourceData, asset *AWSAccountAsset) diag.Diagnostics {
+func setAWSAccountAssetData(d *schema.ResourceData, config *Config, asset *freshservice.Asset) diag.Diagnostics {
 	if err := d.Set("account_name", asset.Name); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("description", asset.Description); err != nil {
+	if err := d.Set("description", config.Defaults.unstampDescription(asset.Description)); err != nil {
 		return diag.FromErr(err)
 	}
 	if err := d.Set("asset_type_id", asset.AssetTypeID); err != nil {
@@ -440,38 +278,14 @@ func setAWSAccountAssetData(d *schema.ResourceData, asset *AWSAccountAsset) diag
 		return diag.FromErr(err)
 	}
 
-	// Extract values from type_fields
-	assetTypeID := asset.AssetTypeID
-	if asset.TypeFields != nil {
-		if accountID, ok := asset.TypeFields[fmt.Sprintf("account_id_%d", assetTypeID)].(string); ok {
-			if err := d.Set("account_id", accountID); err != nil {
-				return diag.FromErr(err)
-			}
-		}
-
-		if poNumber, ok := asset.TypeFields[fmt.Sprintf("po_%d", assetTypeID)].(string); ok {
-			if err := d.Set("po_number", poNumber); err != nil {
-				return diag.FromErr(err)
-			}
-		}
-
-		if owner, ok := asset.TypeFields[fmt.Sprintf("owner_%d", assetTypeID)].(string); ok {
-			if err := d.Set("owner", owner); err != nil {
-				return diag.FromErr(err)
-			}
-		}
-
-		if approver, ok := asset.TypeFields[fmt.Sprintf("approved_by_%d", assetTypeID)].(string); ok {
-			if err := d.Set("approver", approver); err != nil {
-				return diag.FromErr(err)
-			}
-		}
-
-		if environment, ok := asset.TypeFields[fmt.Sprintf("environment_%d", assetTypeID)].(string); ok {
-			if err := d.Set("environment", environment); err != nil {
-				return diag.FromErr(err)
-			}
-		}
+	// Account IDs stored by older versions of the provider are numbers. Those that lost
+	// a leading zero no longer match the configuration and show up as drift.
+	if err := setTypeFieldAttributes(d, config, asset, awsAccountTypeFields); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := setAttributeTypeFieldsAll(d, config, asset, awsAccountTypeFields); err != nil {
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/lcp-llp/terraform-provider-freshservice/freshservice"
+)
+
+func TestResourceAWSAccount_lifecycle(t *testing.T) {
+	h := newTestHarness(t)
+
+	config := map[string]interface{}{
+		"account_name": "Production",
+		"account_id":   "123456789012",
+		"po_number":    "PO-1",
+		"owner":        "aws.admin@example.com",
+		"approver":     "finance@example.com",
+		"environment":  "Production",
+	}
+
+	// Create
+	state := h.apply("freshservice_aws_account", nil, config)
+	requireAttributes(t, state, map[string]string{
+		"account_name":  "Production",
+		"account_id":    "123456789012",
+		"po_number":     "PO-1",
+		"environment":   "Production",
+		"asset_type_id": "56000947175",
+	})
+
+	displayID, _ := strconv.Atoi(state.ID)
+	if owner := h.fake.Asset(displayID)["type_fields"].(map[string]interface{})["owner_56000947175"]; owner != "aws.admin@example.com" {
+		t.Errorf("owner_56000947175 = %#v", owner)
+	}
+
+	// Read
+	state = h.refresh("freshservice_aws_account", state)
+	h.requireNoPlan("freshservice_aws_account", state, config)
+
+	// Update
+	config["environment"] = "Staging"
+	state = h.apply("freshservice_aws_account", state, config)
+	requireAttributes(t, state, map[string]string{"environment": "Staging"})
+	h.requireNoPlan("freshservice_aws_account", h.refresh("freshservice_aws_account", state), config)
+
+	// Drift
+	h.fake.UpdateAsset(displayID, nil, map[string]interface{}{"owner_56000947175": "someone.else@example.com"})
+	state = h.refresh("freshservice_aws_account", state)
+	requireAttributes(t, state, map[string]string{"owner": "someone.else@example.com"})
+	state = h.apply("freshservice_aws_account", state, config)
+	requireAttributes(t, state, map[string]string{"owner": "aws.admin@example.com"})
+
+	// Import
+	imported := h.importState("freshservice_aws_account", state.ID)
+	requireAttributes(t, imported, map[string]string{
+		"account_name": "Production",
+		"owner":        "aws.admin@example.com",
+		"environment":  "Staging",
+	})
+
+	// Delete
+	h.destroy("freshservice_aws_account", state)
+	if asset := h.fake.Asset(displayID); asset["trashed"] != true {
+		t.Errorf("expected asset %d to be in trash", displayID)
+	}
+}
+
+func TestResourceAWSAccount_deletedOutsideTerraform(t *testing.T) {
+	h := newTestHarness(t)
+
+	state := h.apply("freshservice_aws_account", nil, map[string]interface{}{
+		"account_name": "Sandbox",
+		"account_id":   "210987654321",
+	})
+
+	displayID, _ := strconv.Atoi(state.ID)
+	h.fake.DeleteAsset(displayID)
+
+	if h.refresh("freshservice_aws_account", state) != nil {
+		t.Fatal("expected the deleted account to be removed from state")
+	}
+}
+
+func TestResourceAWSAccount_accountID(t *testing.T) {
+	h := newTestHarness(t)
+
+	config := map[string]interface{}{
+		"account_name": "Audit",
+		"account_id":   "012345678901",
+	}
+
+	// Leading zeros are kept
+	state := h.apply("freshservice_aws_account", nil, config)
+	requireAttributes(t, state, map[string]string{"account_id": "012345678901"})
+
+	displayID, _ := strconv.Atoi(state.ID)
+	if accountID := h.fake.Asset(displayID)["type_fields"].(map[string]interface{})["account_id_56000947175"]; accountID != "012345678901" {
+		t.Errorf("expected the account ID to be sent as a string, got %#v", accountID)
+	}
+	h.requireNoPlan("freshservice_aws_account", h.refresh("freshservice_aws_account", state), config)
+
+	// Account IDs stored as numbers are read back
+	h.fake.UpdateAsset(displayID, nil, map[string]interface{}{"account_id_56000947175": float64(123456789012)})
+	state = h.refresh("freshservice_aws_account", state)
+	requireAttributes(t, state, map[string]string{"account_id": "123456789012"})
+
+	// A number that lost its leading zero is drift, and applying writes the string back
+	h.fake.UpdateAsset(displayID, nil, map[string]interface{}{"account_id_56000947175": float64(12345678901)})
+	state = h.refresh("freshservice_aws_account", state)
+	requireAttributes(t, state, map[string]string{"account_id": "12345678901"})
+
+	diff, err := h.plan("freshservice_aws_account", state, config)
+	if err != nil || diff == nil {
+		t.Fatalf("expected the mangled account ID to be planned, got %v (%v)", diff, err)
+	}
+	if attr := diff.Attributes["account_id"]; attr == nil || attr.Old != "12345678901" || attr.New != "012345678901" {
+		t.Errorf("unexpected account_id diff %#v", attr)
+	}
+
+	state = h.apply("freshservice_aws_account", state, config)
+	h.requireNoPlan("freshservice_aws_account", h.refresh("freshservice_aws_account", state), config)
+
+	// Anything but 12 digits is rejected at plan time
+	for _, accountID := range []string{"12345678901", "1234567890123", "12345678901a", "1234-5678-9012"} {
+		config["account_id"] = accountID
+		if _, err := h.plan("freshservice_aws_account", nil, config); err == nil || !strings.Contains(err.Error(), "12-digit AWS account ID") {
+			t.Errorf("expected account ID %q to be rejected, got %v", accountID, err)
+		}
+	}
+}
+
+func TestResourceAWSAccount_numericAccountIDField(t *testing.T) {
+	h := newTestHarness(t)
+
+	// Tenants set up by older versions of the provider have a number field
+	h.fake.SetFieldType(defaultAWSAccountTypeID, "account_id", freshservice.FieldTypeNumber)
+
+	_, err := h.plan("freshservice_aws_account", nil, map[string]interface{}{
+		"account_name": "Audit",
+		"account_id":   "012345678901",
+	})
+	if err == nil || !strings.Contains(err.Error(), "account_id: field account_id_56000947175 of asset type 56000947175 is a number field") {
+		t.Fatalf("expected a plan error about the number field, got %v", err)
+	}
+	if n := h.fake.CountRequests("POST "); n != 0 {
+		t.Errorf("expected no write requests, got %d", n)
+	}
+}
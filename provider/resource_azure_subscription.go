@@ -1,50 +1,43 @@
 package provider
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lcp-llp/terraform-provider-freshservice/freshservice"
 )
 
-// AzureSubscriptionAsset represents a Freshservice Azure Subscription asset
-type AzureSubscriptionAsset struct {
-	ID           int                    `json:"id"`
-	DisplayID    int                    `json:"display_id"`
-	Name         string                 `json:"name"`
-	Description  string                 `json:"description"`
-	AssetTypeID  int                    `json:"asset_type_id"`
-	Impact       string                 `json:"impact"`
-	AuthorType   string                 `json:"author_type"`
-	UsageType    string                 `json:"usage_type"`
-	AssetTag     string                 `json:"asset_tag"`
-	UserID       *int                   `json:"user_id"`
-	LocationID   *int                   `json:"location_id"`
-	DepartmentID *int                   `json:"department_id"`
-	AgentID      *int                   `json:"agent_id"`
-	AssignedOn   *string                `json:"assigned_on"`
-	CreatedAt    time.Time              `json:"created_at"`
-	UpdatedAt    time.Time              `json:"updated_at"`
-	WorkspaceID  int                    `json:"workspace_id"`
-	TypeFields   map[string]interface{} `json:"type_fields"`
+// defaultAzureSubscriptionTypeID is the ID of the Azure subscription asset type the resource manages unless asset_type_id is set
+const defaultAzureSubscriptionTypeID = 56000416566
+
+// azureSubscriptionTypeFields maps Azure subscription type fields (without the asset type ID suffix) to resource attributes
+var azureSubscriptionTypeFields = map[string]string{
+	"tenant_id":       "tenant_id",
+	"subscription_id": "subscription_id",
+	"po":              "po_number",
+	"owner":           "owner",
+	"approver_object": "approver",
+	"environment":     "environment",
+	"eacsp":           "eacsp",
+	"active":          "active",
+	"cloudockit":      "cloudockit",
 }
 
-// AzureSubscriptionAssetResponse represents the API response for Azure subscription asset operations
-type AzureSubscriptionAssetResponse struct {
-	Asset AzureSubscriptionAsset `json:"asset"`
-}
-
-// AzureSubscriptionAssetRequest represents the request body for Azure subscription asset operations
-type AzureSubscriptionAssetRequest struct {
-	Name        string                 `json:"name"`
-	AssetTypeID int                    `json:"asset_type_id"`
-	Description string                 `json:"description,omitempty"`
-	TypeFields  map[string]interface{} `json:"type_fields"`
+// azureSubscriptionFieldResolver maps fields reported in API errors to Azure subscription attributes
+func azureSubscriptionFieldResolver(assetTypeID int) apiFieldResolver {
+	return assetFieldResolver(assetTypeID, map[string]string{
+		"name":          "subscription_name",
+		"description":   "description",
+		"asset_type_id": "asset_type_id",
+		"workspace_id":  "workspace_id",
+	}, azureSubscriptionTypeFields)
 }
 
 func resourceAzureSubscription() *schema.Resource {
@@ -53,8 +46,9 @@ func resourceAzureSubscription() *schema.Resource {
 		ReadContext:   resourceAzureSubscriptionRead,
 		UpdateContext: resourceAzureSubscriptionUpdate,
 		DeleteContext: resourceAzureSubscriptionDelete,
+		CustomizeDiff: customdiff.All(attributeTypeFieldsAllCustomizeDiff(azureSubscriptionTypeFields), customizeTrashedAsset),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importAssetState(defaultAzureSubscriptionTypeID, "subscription_id"),
 		},
 		Description: "Manages a Freshservice Azure Subscription asset",
 
@@ -107,8 +101,8 @@ func resourceAzureSubscription() *schema.Resource {
 			"asset_type_id": {
 				Type:        schema.TypeInt,
 				Optional:    true,
-				Default:     int64(56000416566),
-				Description: "Asset type ID for Azure subscription (default: 56000416566)",
+				Default:     int64(defaultAzureSubscriptionTypeID),
+				Description: fmt.Sprintf("Asset type ID for Azure subscription (default: %d)", defaultAzureSubscriptionTypeID),
 			},
 			"eacsp": {
 				Type:        schema.TypeString,
@@ -128,6 +122,11 @@ func resourceAzureSubscription() *schema.Resource {
 				Default:     "Yes",
 				Description: "Cloudockit field (default: Yes)",
 			},
+			"delete_mode":        assetDeleteModeSchema(),
+			"adopt_existing":     assetAdoptExistingSchema("subscription_id"),
+			"restore_if_trashed": assetRestoreIfTrashedSchema(),
+			"trashed":            assetTrashedSchema(),
+			"type_fields_all":    typeFieldsAllSchema(),
 			// Computed fields
 			"display_id": {
 				Type:        schema.TypeInt,
@@ -150,9 +149,11 @@ func resourceAzureSubscription() *schema.Resource {
 				Description: "Last update timestamp of the asset",
 			},
 			"workspace_id": {
-				Type:        schema.TypeInt,
-				Computed:    true,
-				Description: "Workspace ID of the asset",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Workspace ID of the asset. Defaults to the provider's default_workspace_id, or the default workspace of the account. Changing this moves the asset to the new workspace",
 			},
 		},
 	}
@@ -164,250 +165,98 @@ func resourceAzureSubscriptionCreate(ctx context.Context, d *schema.ResourceData
 	// Get asset type ID
 	assetTypeID := d.Get("asset_type_id").(int)
 
-	// Build type_fields with the specific field names based on asset type ID
-	typeFields := map[string]interface{}{}
-
-	if tenantID := d.Get("tenant_id").(string); tenantID != "" {
-		typeFields[fmt.Sprintf("tenant_id_%d", assetTypeID)] = tenantID
-	}
-
-	if subscriptionID := d.Get("subscription_id").(string); subscriptionID != "" {
-		typeFields[fmt.Sprintf("subscription_id_%d", assetTypeID)] = subscriptionID
-	}
-
-	if poNumber := d.Get("po_number").(string); poNumber != "" {
-		typeFields[fmt.Sprintf("po_%d", assetTypeID)] = poNumber
-	}
-
-	if owner := d.Get("owner").(string); owner != "" {
-		typeFields[fmt.Sprintf("owner_%d", assetTypeID)] = owner
-	}
-
-	if approver := d.Get("approver").(string); approver != "" {
-		typeFields[fmt.Sprintf("approver_object_%d", assetTypeID)] = approver
-	}
-
-	if environment := d.Get("environment").(string); environment != "" {
-		typeFields[fmt.Sprintf("environment_%d", assetTypeID)] = environment
+	// Take over an existing asset with the same subscription ID instead of creating a duplicate
+	existing, diags := adoptAssetByTypeField(ctx, d, config, assetTypeID, "subscription_id")
+	if diags.HasError() {
+		return diags
 	}
-
-	if eacsp := d.Get("eacsp").(string); eacsp != "" {
-		typeFields[fmt.Sprintf("eacsp_%d", assetTypeID)] = eacsp
-	}
-
-	if active := d.Get("active").(string); active != "" {
-		typeFields[fmt.Sprintf("active_%d", assetTypeID)] = active
+	if existing != nil {
+		d.SetId(strconv.Itoa(existing.DisplayID))
+		return resourceAzureSubscriptionUpdate(ctx, d, meta)
 	}
 
-	if cloudockit := d.Get("cloudockit").(string); cloudockit != "" {
-		typeFields[fmt.Sprintf("cloudockit_%d", assetTypeID)] = cloudockit
-	}
+	tflog.Debug(ctx, "Creating Azure subscription asset", map[string]interface{}{"asset_type_id": assetTypeID})
 
 	// Build request body
-	assetReq := AzureSubscriptionAssetRequest{
+	assetReq := &freshservice.AssetRequest{
 		Name:        d.Get("subscription_name").(string),
 		AssetTypeID: assetTypeID,
-		Description: d.Get("description").(string),
-		TypeFields:  typeFields,
+		Description: config.Defaults.stampDescription(d.Get("description").(string)),
+		WorkspaceID: assetWorkspaceID(d, config),
+		TypeFields:  attributeTypeFields(d, assetTypeID, azureSubscriptionTypeFields),
 	}
 
-	// Convert request to JSON
-	jsonData, err := json.Marshal(assetReq)
-	if err != nil {
-		return diag.Errorf("Failed to marshal request: %s", err)
-	}
+	config.Defaults.applyTypeFields(assetReq.TypeFields, assetTypeID, azureSubscriptionTypeFields)
 
-	// Create the request
-	req, err := config.NewRequest(ctx, "POST", "/assets", bytes.NewReader(jsonData))
+	asset, err := config.Assets.Create(ctx, assetReq)
 	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	// Execute the request
-	resp, err := config.DoRequest(req)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	defer resp.Body.Close()
-
-	// Parse response
-	var assetResp AzureSubscriptionAssetResponse
-	if err := json.NewDecoder(resp.Body).Decode(&assetResp); err != nil {
-		return diag.Errorf("Failed to decode response: %s", err)
+		return apiErrorDiagnostics(err, azureSubscriptionFieldResolver(assetTypeID))
 	}
 
 	// Set the resource ID using display_id (which is used for API calls) and other computed fields
-	d.SetId(strconv.Itoa(assetResp.Asset.DisplayID))
+	d.SetId(strconv.Itoa(asset.DisplayID))
 
-	return setAzureSubscriptionAssetData(d, &assetResp.Asset)
+	return setAzureSubscriptionAssetData(d, config, asset)
 }
 
 func resourceAzureSubscriptionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
 
-	// Get the asset display ID (stored as Terraform resource ID)
-	displayID := d.Id()
-
-	// Create the request using display_id
-	endpoint := fmt.Sprintf("/assets/%s", displayID)
-	req, err := config.NewRequest(ctx, "GET", endpoint, nil)
-	if err != nil {
-		return diag.Errorf("Failed to create request for asset %s: %s", displayID, err)
-	}
-
-	// Execute the request
-	resp, err := config.DoRequest(req)
-	if err != nil {
-		return diag.Errorf("Request failed for asset %s: %s", displayID, err)
-	}
-	defer resp.Body.Close()
-
-	// Check for 404 specifically
-	if resp.StatusCode == 404 {
-		d.SetId("")
-		return nil
+	asset, diags := readAssetResource(ctx, d, config)
+	if asset == nil {
+		return diags
 	}
 
-	// Check for other non-200 status codes
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return diag.Errorf("API request failed with status %d for asset %s", resp.StatusCode, displayID)
-	}
-
-	// Parse response
-	var assetResp AzureSubscriptionAssetResponse
-	if err := json.NewDecoder(resp.Body).Decode(&assetResp); err != nil {
-		return diag.Errorf("Failed to decode response for asset %s: %s", displayID, err)
-	}
-
-	return setAzureSubscriptionAssetData(d, &assetResp.Asset)
+	return setAzureSubscriptionAssetData(d, config, asset)
 }
 
 func resourceAzureSubscriptionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
 
+	if update, diags := prepareAssetUpdate(ctx, d, config); !update {
+		return diags
+	}
+
 	// Get the asset display ID (stored as Terraform resource ID)
-	displayID := d.Id()
+	displayID, err := resourceIntID(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	// Get asset type ID
 	assetTypeID := d.Get("asset_type_id").(int)
 
+	tflog.Debug(ctx, "Updating Azure subscription asset", map[string]interface{}{"display_id": displayID, "asset_type_id": assetTypeID})
+
 	// Build request body with all current values (not just changed fields)
-	assetReq := AzureSubscriptionAssetRequest{
+	assetReq := &freshservice.AssetRequest{
 		Name:        d.Get("subscription_name").(string),
 		AssetTypeID: assetTypeID,
-		Description: d.Get("description").(string),
-		TypeFields:  map[string]interface{}{},
-	}
-
-	// Always include all type_fields (not just changed ones)
-	if tenantID := d.Get("tenant_id").(string); tenantID != "" {
-		assetReq.TypeFields[fmt.Sprintf("tenant_id_%d", assetTypeID)] = tenantID
-	}
-
-	if subscriptionID := d.Get("subscription_id").(string); subscriptionID != "" {
-		assetReq.TypeFields[fmt.Sprintf("subscription_id_%d", assetTypeID)] = subscriptionID
-	}
-
-	if poNumber := d.Get("po_number").(string); poNumber != "" {
-		assetReq.TypeFields[fmt.Sprintf("po_%d", assetTypeID)] = poNumber
-	}
-
-	if owner := d.Get("owner").(string); owner != "" {
-		assetReq.TypeFields[fmt.Sprintf("owner_%d", assetTypeID)] = owner
-	}
-
-	if approver := d.Get("approver").(string); approver != "" {
-		assetReq.TypeFields[fmt.Sprintf("approver_object_%d", assetTypeID)] = approver
-	}
-
-	if environment := d.Get("environment").(string); environment != "" {
-		assetReq.TypeFields[fmt.Sprintf("environment_%d", assetTypeID)] = environment
-	}
-
-	if eacsp := d.Get("eacsp").(string); eacsp != "" {
-		assetReq.TypeFields[fmt.Sprintf("eacsp_%d", assetTypeID)] = eacsp
-	}
-
-	if active := d.Get("active").(string); active != "" {
-		assetReq.TypeFields[fmt.Sprintf("active_%d", assetTypeID)] = active
-	}
-
-	if cloudockit := d.Get("cloudockit").(string); cloudockit != "" {
-		assetReq.TypeFields[fmt.Sprintf("cloudockit_%d", assetTypeID)] = cloudockit
-	}
-
-	// Convert request to JSON
-	jsonData, err := json.Marshal(assetReq)
-	if err != nil {
-		return diag.Errorf("Failed to marshal request: %s", err)
+		Description: config.Defaults.stampDescription(d.Get("description").(string)),
+		TypeFields:  attributeTypeFields(d, assetTypeID, azureSubscriptionTypeFields),
 	}
 
-	// Create the request
-	endpoint := fmt.Sprintf("/assets/%s", displayID)
-	req, err := config.NewRequest(ctx, "PUT", endpoint, bytes.NewReader(jsonData))
-	if err != nil {
-		return diag.FromErr(err)
-	}
+	config.Defaults.applyTypeFields(assetReq.TypeFields, assetTypeID, azureSubscriptionTypeFields)
 
-	// Execute the request
-	resp, err := config.DoRequest(req)
+	asset, err := config.Assets.Update(ctx, displayID, assetReq)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(err, azureSubscriptionFieldResolver(assetTypeID))
 	}
-	defer resp.Body.Close()
 
-	// Parse response
-	var assetResp AzureSubscriptionAssetResponse
-	if err := json.NewDecoder(resp.Body).Decode(&assetResp); err != nil {
-		return diag.Errorf("Failed to decode response: %s", err)
-	}
-
-	return setAzureSubscriptionAssetData(d, &assetResp.Asset)
+	return setAzureSubscriptionAssetData(d, config, asset)
 }
 
 func resourceAzureSubscriptionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-
-	// Get the asset display ID (stored as Terraform resource ID)
-	displayID := d.Id()
-
-	// Create the request
-	endpoint := fmt.Sprintf("/assets/%s", displayID)
-	req, err := config.NewRequest(ctx, "DELETE", endpoint, nil)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	// Execute the request
-	resp, err := config.DoRequest(req)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	defer resp.Body.Close()
-
-	// If asset not found, it's already deleted
-	if resp.StatusCode == 404 {
-		d.SetId("")
-		return nil
-	}
-
-	// Check for other errors
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return diag.Errorf("Failed to delete asset: API returned status %d", resp.StatusCode)
-	}
-
-	// Clear the resource ID
-	d.SetId("")
-
-	return nil
+	return deleteAssetResource(ctx, d, config)
 }
 
 // setAzureSubscriptionAssetData sets the Azure subscription asset data in the Terraform state
-func setAzureSubscriptionAssetData(d *schema.ResourceData, asset *AzureSubscriptionAsset) diag.Diagnostics {
+func setAzureSubscriptionAssetData(d *schema.ResourceData, config *Config, asset *freshservice.Asset) diag.Diagnostics {
 	if err := d.Set("subscription_name", asset.Name); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("description", asset.Description); err != nil {
+	if err := d.Set("description", config.Defaults.unstampDescription(asset.Description)); err != nil {
 		return diag.FromErr(err)
 	}
 	if err := d.Set("asset_type_id", asset.AssetTypeID); err != nil {
@@ -429,62 +278,12 @@ func setAzureSubscriptionAssetData(d *schema.ResourceData, asset *AzureSubscript
 		return diag.FromErr(err)
 	}
 
-	// Extract values from type_fields
-	assetTypeID := asset.AssetTypeID
-	if asset.TypeFields != nil {
-		if tenantID, ok := asset.TypeFields[fmt.Sprintf("tenant_id_%d", assetTypeID)].(string); ok {
-			if err := d.Set("tenant_id", tenantID); err != nil {
-				return diag.FromErr(err)
-			}
-		}
-
-		if subscriptionID, ok := asset.TypeFields[fmt.Sprintf("subscription_id_%d", assetTypeID)].(string); ok {
-			if err := d.Set("subscription_id", subscriptionID); err != nil {
-				return diag.FromErr(err)
-			}
-		}
-
-		if poNumber, ok := asset.TypeFields[fmt.Sprintf("po_%d", assetTypeID)].(string); ok {
-			if err := d.Set("po_number", poNumber); err != nil {
-				return diag.FromErr(err)
-			}
-		}
-
-		if owner, ok := asset.TypeFields[fmt.Sprintf("owner_%d", assetTypeID)].(string); ok {
-			if err := d.Set("owner", owner); err != nil {
-				return diag.FromErr(err)
-			}
-		}
-
-		if approver, ok := asset.TypeFields[fmt.Sprintf("approver_object_%d", assetTypeID)].(string); ok {
-			if err := d.Set("approver", approver); err != nil {
-				return diag.FromErr(err)
-			}
-		}
-
-		if environment, ok := asset.TypeFields[fmt.Sprintf("environment_%d", assetTypeID)].(string); ok {
-			if err := d.Set("environment", environment); err != nil {
-				return diag.FromErr(err)
-			}
-		}
-
-		if eacsp, ok := asset.TypeFields[fmt.Sprintf("eacsp_%d", assetTypeID)].(string); ok {
-			if err := d.Set("eacsp", eacsp); err != nil {
-				return diag.FromErr(err)
-			}
-		}
-
-		if active, ok := asset.TypeFields[fmt.Sprintf("active_%d", assetTypeID)].(string); ok {
-			if err := d.Set("active", active); err != nil {
-				return diag.FromErr(err)
-			}
-		}
-
-		if cloudockit, ok := asset.TypeFields[fmt.Sprintf("cloudockit_%d", assetTypeID)].(string); ok {
-			if err := d.Set("cloudockit", cloudockit); err != nil {
-				return diag.FromErr(err)
-			}
-		}
+	if err := setTypeFieldAttributes(d, config, asset, azureSubscriptionTypeFields); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := setAttributeTypeFieldsAll(d, config, asset, azureSubscriptionTypeFields); err != nil {
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestResourceAzureSubscription_lifecycle(t *testing.T) {
+	h := newTestHarness(t)
+
+	config := map[string]interface{}{
+		"subscription_name": "Production",
+		"subscription_id":   "00000000-0000-0000-0000-000000000001",
+		"tenant_id":         "00000000-0000-0000-0000-0000000000aa",
+		"owner":             "azure.admin@example.com",
+		"environment":       "Production",
+	}
+
+	// Create
+	state := h.apply("freshservice_azure_subscription", nil, config)
+	requireAttributes(t, state, map[string]string{
+		"subscription_name": "Production",
+		"subscription_id":   "00000000-0000-0000-0000-000000000001",
+		"eacsp":             "CSP",
+		"active":            "Yes",
+		"asset_type_id":     "56000416566",
+	})
+
+	displayID, _ := strconv.Atoi(state.ID)
+	if eacsp := h.fake.Asset(displayID)["type_fields"].(map[string]interface{})["eacsp_56000416566"]; eacsp != "CSP" {
+		t.Errorf("eacsp_56000416566 = %#v", eacsp)
+	}
+
+	// Read
+	state = h.refresh("freshservice_azure_subscription", state)
+	h.requireNoPlan("freshservice_azure_subscription", state, config)
+
+	// Update
+	config["active"] = "No"
+	state = h.apply("freshservice_azure_subscription", state, config)
+	requireAttributes(t, state, map[string]string{"active": "No"})
+	h.requireNoPlan("freshservice_azure_subscription", h.refresh("freshservice_azure_subscription", state), config)
+
+	// Drift
+	h.fake.UpdateAsset(displayID, map[string]interface{}{"name": "Renamed"}, nil)
+	state = h.refresh("freshservice_azure_subscription", state)
+	requireAttributes(t, state, map[string]string{"subscription_name": "Renamed"})
+	state = h.apply("freshservice_azure_subscription", state, config)
+	requireAttributes(t, state, map[string]string{"subscription_name": "Production"})
+
+	// Import
+	imported := h.importState("freshservice_azure_subscription", state.ID)
+	requireAttributes(t, imported, map[string]string{
+		"subscription_name": "Production",
+		"tenant_id":         "00000000-0000-0000-0000-0000000000aa",
+		"active":            "No",
+	})
+
+	// Delete
+	h.destroy("freshservice_azure_subscription", state)
+	if asset := h.fake.Asset(displayID); asset["trashed"] != true {
+		t.Errorf("expected asset %d to be in trash", displayID)
+	}
+}
+
+func TestResourceAzureSubscription_deletedOutsideTerraform(t *testing.T) {
+	h := newTestHarness(t)
+
+	state := h.apply("freshservice_azure_subscription", nil, map[string]interface{}{
+		"subscription_name": "Sandbox",
+		"subscription_id":   "00000000-0000-0000-0000-000000000002",
+		"tenant_id":         "00000000-0000-0000-0000-0000000000aa",
+	})
+
+	displayID, _ := strconv.Atoi(state.ID)
+	h.fake.DeleteAsset(displayID)
+
+	if h.refresh("freshservice_azure_subscription", state) != nil {
+		t.Fatal("expected the deleted subscription to be removed from state")
+	}
+}
@@ -0,0 +1,305 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// cloudAssetAPIFields maps asset fields reported in API errors to cloud asset attributes
+var cloudAssetAPIFields = map[string]string{
+	"name":          "name",
+	"description":   "description",
+	"asset_type_id": "asset_type_id",
+	"impact":        "impact",
+	"usage_type":    "usage_type",
+	"asset_tag":     "asset_tag",
+	"workspace_id":  "workspace_id",
+}
+
+func resourceCloudAsset() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCloudAssetCreate,
+		ReadContext:   resourceCloudAssetRead,
+		UpdateContext: resourceCloudAssetUpdate,
+		DeleteContext: resourceCloudAssetDelete,
+		CustomizeDiff: customdiff.All(resourceCloudAssetCustomizeDiff, customizeTrashedAsset),
+		Importer: &schema.ResourceImporter{
+			StateContext: importAssetState(0),
+		},
+		Description: "Manages a Freshservice asset of any asset type, validating type_fields against the field definitions of the asset type",
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the asset (contains display_id value)",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the asset",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the asset",
+			},
+			"asset_type_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true, // Asset type cannot be changed after creation
+				Description: "Asset type ID. The field definitions of this asset type are used to validate type_fields",
+			},
+			"impact": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "low",
+				Description: "Impact level of the asset (low, medium, high)",
+			},
+			"usage_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "permanent",
+				Description: "Usage type of the asset (permanent, loaner)",
+			},
+			"type_fields": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Custom type fields of the asset type. Keys are field names with or without the asset type ID suffix (e.g. 'account_id' or 'account_id_56000947175'). Values are converted to the field's data type",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"delete_mode":        assetDeleteModeSchema(),
+			"adopt_existing":     assetAdoptExistingSchema("asset_tag"),
+			"restore_if_trashed": assetRestoreIfTrashedSchema(),
+			"trashed":            assetTrashedSchema(),
+			"type_fields_all":    typeFieldsAllSchema(),
+			// Computed fields
+			"display_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Display ID of the asset",
+			},
+			"asset_tag": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Asset tag. Freshservice generates one when it is not set",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Creation timestamp of the asset",
+			},
+			"updated_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Last update timestamp of the asset",
+			},
+			"workspace_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Workspace ID of the asset. Defaults to the provider's default_workspace_id, or the default workspace of the account. Changing this moves the asset to the new workspace",
+			},
+		},
+	}
+}
+
+// resourceCloudAssetCustomizeDiff validates type_fields against the asset type's field definitions at plan time,
+// and plans type_fields_all with the type fields set by the provider defaults. Keys the asset type has no field
+// for are left to apply, as a freshservice_asset_type_field in the same configuration may create the field.
+func resourceCloudAssetCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// Values computed by other resources can only be checked once they are known
+	if !d.NewValueKnown("asset_type_id") || !d.NewValueKnown("type_fields") {
+		return d.SetNewComputed("type_fields_all")
+	}
+
+	config := meta.(*Config)
+	fields, err := config.getAssetTypeFields(ctx, d.Get("asset_type_id").(int))
+	if err != nil {
+		return err
+	}
+
+	typeFields := config.Defaults.mergeTypeFields(fields, d.Get("type_fields").(map[string]interface{}))
+	unknown := unknownTypeFieldKeys(fields, typeFields)
+
+	known := make(map[string]interface{}, len(typeFields))
+	for key, value := range typeFields {
+		if !slices.Contains(unknown, key) {
+			known[key] = value
+		}
+	}
+	if _, _, err := expandCloudAssetTypeFields(fields, known); err != nil {
+		return err
+	}
+
+	if len(unknown) > 0 {
+		return d.SetNewComputed("type_fields_all")
+	}
+	return d.SetNew("type_fields_all", typeFields)
+}
+
+// validateTypeFieldKeys returns the config validation of freshservice_cloud_asset failing on
+// type_fields keys the asset type has no field for, when the asset type is known. Terraform
+// validates the configuration again with the configured provider before planning, so meta
+// only returns the provider config then.
+func validateTypeFieldKeys(meta func() interface{}) schema.ValidateRawResourceConfigFunc {
+	return func(ctx context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+		config, ok := meta().(*Config)
+		if !ok || !req.RawConfig.IsWhollyKnown() {
+			return
+		}
+
+		assetTypeID := req.RawConfig.GetAttr("asset_type_id")
+		rawTypeFields := req.RawConfig.GetAttr("type_fields")
+		if assetTypeID.IsNull() || rawTypeFields.IsNull() {
+			return
+		}
+
+		id, _ := assetTypeID.AsBigFloat().Int64()
+		fields, err := config.getAssetTypeFields(ctx, int(id))
+		if err != nil {
+			// Planning reports the error
+			return
+		}
+
+		typeFields := make(map[string]interface{})
+		for key := range rawTypeFields.AsValueMap() {
+			typeFields[key] = nil
+		}
+		for _, key := range unknownTypeFieldKeys(fields, typeFields) {
+			resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("Unknown type field %q", key),
+				Detail:        fmt.Sprintf("Asset type %d has no field %s. Fields added to an existing asset type must be applied before they are used. Available fields: %s", fields.AssetTypeID, key, strings.Join(fields.Names(), ", ")),
+				AttributePath: cty.GetAttrPath("type_fields").IndexString(key),
+			})
+		}
+	}
+}
+
+// unknownTypeFieldKeys returns the sorted type_fields keys the asset type has no field for
+func unknownTypeFieldKeys(fields *assetTypeFieldSet, raw map[string]interface{}) []string {
+	var unknown []string
+	for key := range raw {
+		if _, ok := fields.Lookup(key); !ok {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+func resourceCloudAssetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	// Take over an existing asset with the same asset tag instead of creating a duplicate
+	existing, diags := adoptAssetByTag(ctx, d, config)
+	if diags.HasError() {
+		return diags
+	}
+	if existing != nil {
+		d.SetId(strconv.Itoa(existing.DisplayID))
+		return resourceCloudAssetUpdate(ctx, d, meta)
+	}
+
+	return writeAsset(ctx, d, config, cloudAssetKind)
+}
+
+func resourceCloudAssetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	asset, diags := readAssetResource(ctx, d, config)
+	if asset == nil {
+		return diags
+	}
+
+	return setAssetResourceData(ctx, d, config, cloudAssetKind, asset)
+}
+
+func resourceCloudAssetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	if update, diags := prepareAssetUpdate(ctx, d, config); !update {
+		return diags
+	}
+
+	return writeAsset(ctx, d, config, cloudAssetKind)
+}
+
+func resourceCloudAssetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	return deleteAssetResource(ctx, d, config)
+}
+
+// cloudAssetKind is the kind of freshservice_cloud_asset, which only sends type fields
+// defined on the asset type
+var cloudAssetKind = assetResourceKind{
+	name:             "cloud asset",
+	apiFields:        cloudAssetAPIFields,
+	expandTypeFields: expandCloudAssetTypeFields,
+}
+
+// expandCloudAssetTypeFields validates configured type_fields against the field definitions
+// and converts them for the API. It returns the converted values keyed by full field name
+// and the configured key for each full field name. Every problem found is reported at once.
+func expandCloudAssetTypeFields(fields *assetTypeFieldSet, raw map[string]interface{}) (map[string]interface{}, map[string]string, error) {
+	typeFields := make(map[string]interface{})
+	keys := make(map[string]string)
+	var errs []error
+
+	configuredKeys := make([]string, 0, len(raw))
+	for key := range raw {
+		configuredKeys = append(configuredKeys, key)
+	}
+	sort.Strings(configuredKeys)
+
+	for _, key := range configuredKeys {
+		field, ok := fields.Lookup(key)
+		if !ok {
+			errs = append(errs, fmt.Errorf("type_fields.%s: unknown field for asset type %d, available fields: %s",
+				key, fields.AssetTypeID, strings.Join(fields.Names(), ", ")))
+			continue
+		}
+		if other, ok := keys[field.Name]; ok {
+			errs = append(errs, fmt.Errorf("type_fields.%s: field %s is already set by type_fields.%s", key, field.Name, other))
+			continue
+		}
+
+		value, err := field.ConvertValue(raw[key].(string))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("type_fields.%s: invalid %s value: %s", key, field.DataType, err))
+			continue
+		}
+
+		typeFields[field.Name] = value
+		keys[field.Name] = key
+	}
+
+	for _, field := range fields.Fields {
+		if _, ok := keys[field.Name]; field.Mandatory && !ok {
+			errs = append(errs, fmt.Errorf("type_fields.%s: required field %q of asset type %d is missing",
+				field.ShortName(), field.Label, fields.AssetTypeID))
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, nil, errors.Join(errs...)
+	}
+
+	return typeFields, keys, nil
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceCloudAsset_lifecycle(t *testing.T) {
+	h := newTestHarness(t)
+
+	config := map[string]interface{}{
+		"name":          "Build Server",
+		"asset_type_id": testLaptopTypeID,
+		"type_fields": map[string]interface{}{
+			"serial":   "SN-0001",
+			"product":  "ThinkPad",
+			"quantity": "1",
+		},
+	}
+
+	// Create, with fields inherited from the parent asset type
+	state := h.apply("freshservice_cloud_asset", nil, config)
+	requireAttributes(t, state, map[string]string{
+		"name":                 "Build Server",
+		"type_fields.%":        "3",
+		"type_fields.serial":   "SN-0001",
+		"type_fields.product":  "ThinkPad",
+		"type_fields.quantity": "1",
+	})
+
+	displayID, _ := strconv.Atoi(state.ID)
+	typeFields := h.fake.Asset(displayID)["type_fields"].(map[string]interface{})
+	if typeFields["serial_26"] != "SN-0001" || typeFields["product_25"] != "ThinkPad" || typeFields["quantity_25"] != float64(1) {
+		t.Errorf("unexpected type fields sent: %#v", typeFields)
+	}
+
+	// Read
+	state = h.refresh("freshservice_cloud_asset", state)
+	h.requireNoPlan("freshservice_cloud_asset", state, config)
+
+	// Update
+	config["type_fields"].(map[string]interface{})["quantity"] = "2"
+	state = h.apply("freshservice_cloud_asset", state, config)
+	requireAttributes(t, state, map[string]string{"type_fields.quantity": "2"})
+	h.requireNoPlan("freshservice_cloud_asset", h.refresh("freshservice_cloud_asset", state), config)
+
+	// Drift on a managed field is detected, unmanaged fields are ignored
+	h.fake.UpdateAsset(displayID, nil, map[string]interface{}{"product_25": "Latitude", "cost_25": 10.0})
+	state = h.refresh("freshservice_cloud_asset", state)
+	requireAttributes(t, state, map[string]string{"type_fields.%": "3", "type_fields.product": "Latitude"})
+	state = h.apply("freshservice_cloud_asset", state, config)
+	h.requireNoPlan("freshservice_cloud_asset", h.refresh("freshservice_cloud_asset", state), config)
+
+	// Import brings in every field with a value
+	imported := h.importState("freshservice_cloud_asset", state.ID)
+	requireAttributes(t, imported, map[string]string{
+		"asset_type_id":        strconv.Itoa(testLaptopTypeID),
+		"type_fields.%":        "4",
+		"type_fields.cost":     "10",
+		"type_fields.product":  "ThinkPad",
+		"type_fields.serial":   "SN-0001",
+		"type_fields.quantity": "2",
+	})
+
+	// Delete
+	h.destroy("freshservice_cloud_asset", state)
+	if asset := h.fake.Asset(displayID); asset["trashed"] != true {
+		t.Errorf("expected asset %d to be in trash", displayID)
+	}
+	if h.refresh("freshservice_cloud_asset", state) != nil {
+		t.Error("expected the trashed asset to be removed from state")
+	}
+}
+
+func TestResourceCloudAsset_planValidation(t *testing.T) {
+	h := newTestHarness(t)
+
+	_, err := h.plan("freshservice_cloud_asset", nil, map[string]interface{}{
+		"name":          "Build Server",
+		"asset_type_id": testLaptopTypeID,
+		"type_fields": map[string]interface{}{
+			"colour":      "red",
+			"environment": "Staging",
+			"cost":        "cheap",
+		},
+	})
+	if err == nil {
+		t.Fatal("expected plan errors")
+	}
+	for _, want := range []string{
+		"type_fields.environment: invalid dropdown value",
+		"type_fields.cost: invalid decimal value",
+		`type_fields.serial: required field "Serial"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in %s", want, err)
+		}
+	}
+
+	if n := h.fake.CountRequests("POST "); n != 0 {
+		t.Errorf("expected no write requests, got %d", n)
+	}
+}
+
+func TestResourceCloudAsset_unknownTypeFields(t *testing.T) {
+	h := newTestHarness(t)
+
+	assetType := h.apply("freshservice_asset_type", nil, map[string]interface{}{"name": "Cloud Account"})
+	assetTypeID, _ := strconv.Atoi(assetType.ID)
+	config := map[string]interface{}{
+		"name":          "Production account",
+		"asset_type_id": assetType.ID,
+		"type_fields":   map[string]interface{}{"environment": "Production"},
+	}
+
+	// Validating with the configured provider fails on the key
+	resp := &schema.ValidateResourceConfigFuncResponse{}
+	validateTypeFieldKeys(func() interface{} { return h.config })(context.Background(), schema.ValidateResourceConfigFuncRequest{
+		RawConfig: cty.ObjectVal(map[string]cty.Value{
+			"asset_type_id": cty.NumberIntVal(int64(assetTypeID)),
+			"type_fields":   cty.MapVal(map[string]cty.Value{"environment": cty.StringVal("Production")}),
+		}),
+	}, resp)
+	if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Severity != diag.Error || !strings.Contains(resp.Diagnostics[0].Summary, `"environment"`) {
+		t.Errorf("expected an error about environment, got %#v", resp.Diagnostics)
+	}
+
+	// Without a known asset type, validation can't tell
+	resp = &schema.ValidateResourceConfigFuncResponse{}
+	validateTypeFieldKeys(func() interface{} { return h.config })(context.Background(), schema.ValidateResourceConfigFuncRequest{
+		RawConfig: cty.ObjectVal(map[string]cty.Value{
+			"asset_type_id": cty.UnknownVal(cty.Number),
+			"type_fields":   cty.MapVal(map[string]cty.Value{"environment": cty.StringVal("Production")}),
+		}),
+	}, resp)
+	if len(resp.Diagnostics) != 0 {
+		t.Errorf("expected no diagnostics for an unknown asset type, got %#v", resp.Diagnostics)
+	}
+
+	// Planning without that validation leaves the merged type fields unknown
+	diff, err := h.plan("freshservice_cloud_asset", nil, config)
+	if err != nil {
+		t.Fatalf("expected plan to succeed: %s", err)
+	}
+	if attr := diff.Attributes["type_fields_all.%"]; attr == nil || !attr.NewComputed {
+		t.Errorf("expected type_fields_all to be unknown, got %#v", attr)
+	}
+
+	// Applying fails while the field doesn't exist
+	if _, err := h.tryApply("freshservice_cloud_asset", nil, config); err == nil || !strings.Contains(err.Error(), "type_fields.environment: unknown field") {
+		t.Fatalf("expected an unknown field error, got %v", err)
+	}
+	if n := h.fake.CountRequests("POST /api/v2/assets"); n != 0 {
+		t.Errorf("expected no asset to be created, got %d requests", n)
+	}
+
+	// Once the field exists, the asset is created
+	h.apply("freshservice_asset_type_field", nil, map[string]interface{}{
+		"asset_type_id": assetType.ID,
+		"label":         "Environment",
+		"field_type":    "text",
+	})
+	state := h.apply("freshservice_cloud_asset", nil, config)
+	requireAttributes(t, state, map[string]string{"type_fields.environment": "Production"})
+}
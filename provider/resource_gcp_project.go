@@ -1,52 +1,41 @@
 package provider
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"log"
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lcp-llp/terraform-provider-freshservice/freshservice"
 )
 
-// GCPProjectAsset represents a Freshservice GCP Project asset
-type GCPProjectAsset struct {
-	ID           int                    `json:"id"`
-	DisplayID    int                    `json:"display_id"`
-	Name         string                 `json:"name"`
-	Description  string                 `json:"description"`
-	AssetTypeID  int                    `json:"asset_type_id"`
-	Impact       string                 `json:"impact"`
-	AuthorType   string                 `json:"author_type"`
-	UsageType    string                 `json:"usage_type"`
-	AssetTag     string                 `json:"asset_tag"`
-	UserID       *int                   `json:"user_id"`
-	LocationID   *int                   `json:"location_id"`
-	DepartmentID *int                   `json:"department_id"`
-	AgentID      *int                   `json:"agent_id"`
-	AssignedOn   *string                `json:"assigned_on"`
-	CreatedAt    time.Time              `json:"created_at"`
-	UpdatedAt    time.Time              `json:"updated_at"`
-	WorkspaceID  int                    `json:"workspace_id"`
-	TypeFields   map[string]interface{} `json:"type_fields"`
+// defaultGCPProjectTypeID is the ID of the GCP project asset type the resource manages unless asset_type_id is set
+const defaultGCPProjectTypeID = 56000979438
+
+// gcpProjectTypeFields maps GCP project type fields (without the asset type ID suffix) to resource attributes
+var gcpProjectTypeFields = map[string]string{
+	"project_id":   "project_id",
+	"project_name": "project_name",
+	"po":           "po_number",
+	"owner":        "owner",
+	"approved_by":  "approver",
+	"environment":  "environment",
+	"active":       "active",
 }
 
-// GCPProjectAssetResponse represents the API response for GCP project asset operations
-type GCPProjectAssetResponse struct {
-	Asset GCPProjectAsset `json:"asset"`
-}
-
-// GCPProjectAssetRequest represents the request body for GCP project asset operations
-type GCPProjectAssetRequest struct {
-	Name        string                 `json:"name"`
-	AssetTypeID int                    `json:"asset_type_id"`
-	Description string                 `json:"description,omitempty"`
-	TypeFields  map[string]interface{} `json:"type_fields"`
+// gcpProjectFieldResolver maps fields reported in API errors to GCP project attributes
+func gcpProjectFieldResolver(assetTypeID int) apiFieldResolver {
+	return assetFieldResolver(assetTypeID, map[string]string{
+		"name":          "project_name",
+		"description":   "description",
+		"asset_type_id": "asset_type_id",
+		"workspace_id":  "workspace_id",
+	}, gcpProjectTypeFields)
 }
 
 func resourceGCPProject() *schema.Resource {
@@ -55,8 +44,9 @@ func resourceGCPProject() *schema.Resource {
 		ReadContext:   resourceGCPProjectRead,
 		UpdateContext: resourceGCPProjectUpdate,
 		DeleteContext: resourceGCPProjectDelete,
+		CustomizeDiff: customdiff.All(attributeTypeFieldsAllCustomizeDiff(gcpProjectTypeFields), customizeTrashedAsset),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importAssetState(defaultGCPProjectTypeID, "project_id"),
 		},
 		Description: "Manages a Freshservice GCP Project asset",
 
@@ -104,8 +94,8 @@ func resourceGCPProject() *schema.Resource {
 			"asset_type_id": {
 				Type:        schema.TypeInt,
 				Optional:    true,
-				Default:     int64(56000979438),
-				Description: "Asset type ID for GCP project (default: 56000979438)",
+				Default:     int64(defaultGCPProjectTypeID),
+				Description: fmt.Sprintf("Asset type ID for GCP project (default: %d)", defaultGCPProjectTypeID),
 			},
 			"active": {
 				Type:        schema.TypeString,
@@ -113,6 +103,11 @@ func resourceGCPProject() *schema.Resource {
 				Default:     "Yes",
 				Description: "Active status (default: Yes)",
 			},
+			"delete_mode":        assetDeleteModeSchema(),
+			"adopt_existing":     assetAdoptExistingSchema("project_id"),
+			"restore_if_trashed": assetRestoreIfTrashedSchema(),
+			"trashed":            assetTrashedSchema(),
+			"type_fields_all":    typeFieldsAllSchema(),
 			// Computed fields
 			"display_id": {
 				Type:        schema.TypeInt,
@@ -135,9 +130,11 @@ func resourceGCPProject() *schema.Resource {
 				Description: "Last update timestamp of the asset",
 			},
 			"workspace_id": {
-				Type:        schema.TypeInt,
-				Computed:    true,
-				Description: "Workspace ID of the asset",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Workspace ID of the asset. Defaults to the provider's default_workspace_id, or the default workspace of the account. Changing this moves the asset to the new workspace",
 			},
 		},
 	}
@@ -149,288 +146,98 @@ func resourceGCPProjectCreate(ctx context.Context, d *schema.ResourceData, meta
 	// Get asset type ID
 	assetTypeID := d.Get("asset_type_id").(int)
 
-	log.Printf("[DEBUG] Creating GCP project asset with type ID: %d", assetTypeID)
-
-	// Build type_fields with the specific field names based on asset type ID
-	typeFields := map[string]interface{}{}
-
-	if projectID := d.Get("project_id").(string); projectID != "" {
-		typeFields[fmt.Sprintf("project_id_%d", assetTypeID)] = projectID
-		log.Printf("[DEBUG] Added project_id_%d: %s", assetTypeID, projectID)
+	// Take over an existing asset with the same project ID instead of creating a duplicate
+	existing, diags := adoptAssetByTypeField(ctx, d, config, assetTypeID, "project_id")
+	if diags.HasError() {
+		return diags
 	}
-
-	if projectName := d.Get("project_name").(string); projectName != "" {
-		typeFields[fmt.Sprintf("project_name_%d", assetTypeID)] = projectName
-		log.Printf("[DEBUG] Added project_name_%d: %s", assetTypeID, projectName)
+	if existing != nil {
+		d.SetId(strconv.Itoa(existing.DisplayID))
+		return resourceGCPProjectUpdate(ctx, d, meta)
 	}
 
-	if poNumber := d.Get("po_number").(string); poNumber != "" {
-		typeFields[fmt.Sprintf("po_%d", assetTypeID)] = poNumber
-		log.Printf("[DEBUG] Added po_%d: %s", assetTypeID, poNumber)
-	}
-
-	if owner := d.Get("owner").(string); owner != "" {
-		typeFields[fmt.Sprintf("owner_%d", assetTypeID)] = owner
-		log.Printf("[DEBUG] Added owner_%d: %s", assetTypeID, owner)
-	}
-
-	if approver := d.Get("approver").(string); approver != "" {
-		typeFields[fmt.Sprintf("approved_by_%d", assetTypeID)] = approver
-		log.Printf("[DEBUG] Added approved_by_%d: %s", assetTypeID, approver)
-	}
-
-	if environment := d.Get("environment").(string); environment != "" {
-		typeFields[fmt.Sprintf("environment_%d", assetTypeID)] = environment
-		log.Printf("[DEBUG] Added environment_%d: %s", assetTypeID, environment)
-	}
-
-	if active := d.Get("active").(string); active != "" {
-		typeFields[fmt.Sprintf("active_%d", assetTypeID)] = active
-		log.Printf("[DEBUG] Added active_%d: %s", assetTypeID, active)
-	}
-
-	log.Printf("[DEBUG] Final type_fields for GCP project: %+v", typeFields)
+	tflog.Debug(ctx, "Creating GCP project asset", map[string]interface{}{"asset_type_id": assetTypeID})
 
 	// Build request body
-	assetReq := GCPProjectAssetRequest{
+	assetReq := &freshservice.AssetRequest{
 		Name:        d.Get("project_name").(string),
 		AssetTypeID: assetTypeID,
-		Description: d.Get("description").(string),
-		TypeFields:  typeFields,
-	}
-
-	log.Printf("[DEBUG] GCP project asset request payload: Name=%s, AssetTypeID=%d, Description=%s",
-		assetReq.Name, assetReq.AssetTypeID, assetReq.Description)
-
-	// Convert request to JSON
-	jsonData, err := json.Marshal(assetReq)
-	if err != nil {
-		return diag.Errorf("Failed to marshal request: %s", err)
-	}
-
-	log.Printf("[DEBUG] GCP project request JSON: %s", string(jsonData))
-
-	// Create the request
-	req, err := config.NewRequest(ctx, "POST", "/assets", bytes.NewReader(jsonData))
-	if err != nil {
-		return diag.FromErr(err)
+		Description: config.Defaults.stampDescription(d.Get("description").(string)),
+		WorkspaceID: assetWorkspaceID(d, config),
+		TypeFields:  attributeTypeFields(d, assetTypeID, gcpProjectTypeFields),
 	}
 
-	log.Printf("[DEBUG] Making GCP project API request to: %s", req.URL.String())
-	log.Printf("[DEBUG] Request headers: %+v", req.Header)
+	config.Defaults.applyTypeFields(assetReq.TypeFields, assetTypeID, gcpProjectTypeFields)
 
-	// Execute the request
-	resp, err := config.DoRequest(req)
+	asset, err := config.Assets.Create(ctx, assetReq)
 	if err != nil {
-		log.Printf("[ERROR] GCP project API request failed: %s", err)
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(err, gcpProjectFieldResolver(assetTypeID))
 	}
-	defer resp.Body.Close()
-
-	log.Printf("[DEBUG] GCP project API response status: %d %s", resp.StatusCode, resp.Status)
-	log.Printf("[DEBUG] Response headers: %+v", resp.Header)
-
-	// Read response body for logging before decoding
-	respBodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		log.Printf("[ERROR] Failed to read GCP project response body: %s", err)
-		return diag.Errorf("Failed to read response body: %s", err)
-	}
-
-	log.Printf("[DEBUG] GCP project response body: %s", string(respBodyBytes))
-
-	// Parse response
-	var assetResp GCPProjectAssetResponse
-	if err := json.Unmarshal(respBodyBytes, &assetResp); err != nil {
-		log.Printf("[ERROR] Failed to decode GCP project response: %s", err)
-		log.Printf("[ERROR] Response body was: %s", string(respBodyBytes))
-		return diag.Errorf("Failed to decode response: %s", err)
-	}
-
-	log.Printf("[DEBUG] Successfully created GCP project asset with ID: %d", assetResp.Asset.DisplayID)
 
 	// Set the resource ID using display_id (which is used for API calls) and other computed fields
-	d.SetId(strconv.Itoa(assetResp.Asset.DisplayID))
+	d.SetId(strconv.Itoa(asset.DisplayID))
 
-	return setGCPProjectAssetData(d, &assetResp.Asset)
+	return setGCPProjectAssetData(d, config, asset)
 }
 
 func resourceGCPProjectRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
 
-	// Get the asset display ID (stored as Terraform resource ID)
-	displayID := d.Id()
-
-	// Create the request using display_id
-	endpoint := fmt.Sprintf("/assets/%s", displayID)
-	req, err := config.NewRequest(ctx, "GET", endpoint, nil)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	// Execute the request
-	resp, err := config.DoRequest(req)
-	if err != nil {
-		// If asset not found, remove from state
-		if resp != nil && resp.StatusCode == 404 {
-			d.SetId("")
-			return nil
-		}
-		return diag.FromErr(err)
-	}
-	defer resp.Body.Close()
-
-	// Parse response
-	var assetResp GCPProjectAssetResponse
-	if err := json.NewDecoder(resp.Body).Decode(&assetResp); err != nil {
-		return diag.Errorf("Failed to decode response: %s", err)
+	asset, diags := readAssetResource(ctx, d, config)
+	if asset == nil {
+		return diags
 	}
 
-	return setGCPProjectAssetData(d, &assetResp.Asset)
+	return setGCPProjectAssetData(d, config, asset)
 }
 
 func resourceGCPProjectUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
 
+	if update, diags := prepareAssetUpdate(ctx, d, config); !update {
+		return diags
+	}
+
 	// Get the asset display ID (stored as Terraform resource ID)
-	displayID := d.Id()
+	displayID, err := resourceIntID(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	// Get asset type ID
 	assetTypeID := d.Get("asset_type_id").(int)
 
-	log.Printf("[DEBUG] Updating GCP project asset %s with type ID: %d", displayID, assetTypeID)
+	tflog.Debug(ctx, "Updating GCP project asset", map[string]interface{}{"display_id": displayID, "asset_type_id": assetTypeID})
 
 	// Build request body with all current values (not just changed fields)
-	assetReq := GCPProjectAssetRequest{
+	assetReq := &freshservice.AssetRequest{
 		Name:        d.Get("project_name").(string),
 		AssetTypeID: assetTypeID,
-		Description: d.Get("description").(string),
-		TypeFields:  map[string]interface{}{},
+		Description: config.Defaults.stampDescription(d.Get("description").(string)),
+		TypeFields:  attributeTypeFields(d, assetTypeID, gcpProjectTypeFields),
 	}
 
-	// Always include all type_fields (not just changed ones)
-	if projectID := d.Get("project_id").(string); projectID != "" {
-		assetReq.TypeFields[fmt.Sprintf("project_id_%d", assetTypeID)] = projectID
-		log.Printf("[DEBUG] Added project_id_%d: %s", assetTypeID, projectID)
-	}
-
-	if projectName := d.Get("project_name").(string); projectName != "" {
-		assetReq.TypeFields[fmt.Sprintf("project_name_%d", assetTypeID)] = projectName
-		log.Printf("[DEBUG] Added project_name_%d: %s", assetTypeID, projectName)
-	}
-
-	if poNumber := d.Get("po_number").(string); poNumber != "" {
-		assetReq.TypeFields[fmt.Sprintf("po_%d", assetTypeID)] = poNumber
-		log.Printf("[DEBUG] Added po_%d: %s", assetTypeID, poNumber)
-	}
-
-	if owner := d.Get("owner").(string); owner != "" {
-		assetReq.TypeFields[fmt.Sprintf("owner_%d", assetTypeID)] = owner
-		log.Printf("[DEBUG] Added owner_%d: %s", assetTypeID, owner)
-	}
-
-	if approver := d.Get("approver").(string); approver != "" {
-		assetReq.TypeFields[fmt.Sprintf("approved_by_%d", assetTypeID)] = approver
-		log.Printf("[DEBUG] Added approved_by_%d: %s", assetTypeID, approver)
-	}
-
-	if environment := d.Get("environment").(string); environment != "" {
-		assetReq.TypeFields[fmt.Sprintf("environment_%d", assetTypeID)] = environment
-		log.Printf("[DEBUG] Added environment_%d: %s", assetTypeID, environment)
-	}
-
-	if active := d.Get("active").(string); active != "" {
-		assetReq.TypeFields[fmt.Sprintf("active_%d", assetTypeID)] = active
-		log.Printf("[DEBUG] Added active_%d: %s", assetTypeID, active)
-	}
+	config.Defaults.applyTypeFields(assetReq.TypeFields, assetTypeID, gcpProjectTypeFields)
 
-	log.Printf("[DEBUG] Final type_fields for GCP project update: %+v", assetReq.TypeFields)
-
-	// Convert request to JSON
-	jsonData, err := json.Marshal(assetReq)
-	if err != nil {
-		return diag.Errorf("Failed to marshal request: %s", err)
-	}
-
-	log.Printf("[DEBUG] GCP project update request JSON: %s", string(jsonData))
-
-	// Create the request
-	endpoint := fmt.Sprintf("/assets/%s", displayID)
-	req, err := config.NewRequest(ctx, "PUT", endpoint, bytes.NewReader(jsonData))
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	log.Printf("[DEBUG] Making GCP project update API request to: %s", req.URL.String())
-
-	// Execute the request
-	resp, err := config.DoRequest(req)
+	asset, err := config.Assets.Update(ctx, displayID, assetReq)
 	if err != nil {
-		log.Printf("[ERROR] GCP project update API request failed: %s", err)
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(err, gcpProjectFieldResolver(assetTypeID))
 	}
-	defer resp.Body.Close()
-
-	log.Printf("[DEBUG] GCP project update API response status: %d %s", resp.StatusCode, resp.Status)
 
-	// Read response body for logging before decoding
-	respBodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		log.Printf("[ERROR] Failed to read GCP project update response body: %s", err)
-		return diag.Errorf("Failed to read response body: %s", err)
-	}
-
-	log.Printf("[DEBUG] GCP project update response body: %s", string(respBodyBytes))
-
-	// Parse response
-	var assetResp GCPProjectAssetResponse
-	if err := json.Unmarshal(respBodyBytes, &assetResp); err != nil {
-		log.Printf("[ERROR] Failed to decode GCP project update response: %s", err)
-		log.Printf("[ERROR] Response body was: %s", string(respBodyBytes))
-		return diag.Errorf("Failed to decode response: %s", err)
-	}
-
-	log.Printf("[DEBUG] Successfully updated GCP project asset with ID: %d", assetResp.Asset.DisplayID)
-
-	return setGCPProjectAssetData(d, &assetResp.Asset)
+	return setGCPProjectAssetData(d, config, asset)
 }
 
 func resourceGCPProjectDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-
-	// Get the asset display ID (stored as Terraform resource ID)
-	displayID := d.Id()
-
-	// Create the request
-	endpoint := fmt.Sprintf("/assets/%s", displayID)
-	req, err := config.NewRequest(ctx, "DELETE", endpoint, nil)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	// Execute the request
-	resp, err := config.DoRequest(req)
-	if err != nil {
-		// If asset not found, it's already deleted
-		if resp != nil && resp.StatusCode == 404 {
-			return nil
-		}
-		return diag.FromErr(err)
-	}
-	defer resp.Body.Close()
-
-	// Clear the resource ID
-	d.SetId("")
-
-	return nil
+	return deleteAssetResource(ctx, d, config)
 }
 
 // setGCPProjectAssetData sets the GCP project asset data in the Terraform state
-func setGCPProjectAssetData(d *schema.ResourceData, asset *GCPProjectAsset) diag.Diagnostics {
+func setGCPProjectAssetData(d *schema.ResourceData, config *Config, asset *freshservice.Asset) diag.Diagnostics {
 	if err := d.Set("project_name", asset.Name); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("description", asset.Description); err != nil {
+	if err := d.Set("description", config.Defaults.unstampDescription(asset.Description)); err != nil {
 		return diag.FromErr(err)
 	}
 	if err := d.Set("asset_type_id", asset.AssetTypeID); err != nil {
@@ -452,44 +259,12 @@ func setGCPProjectAssetData(d *schema.ResourceData, asset *GCPProjectAsset) diag
 		return diag.FromErr(err)
 	}
 
-	// Extract values from type_fields
-	assetTypeID := asset.AssetTypeID
-	if asset.TypeFields != nil {
-		if projectID, ok := asset.TypeFields[fmt.Sprintf("project_id_%d", assetTypeID)].(string); ok {
-			if err := d.Set("project_id", projectID); err != nil {
-				return diag.FromErr(err)
-			}
-		}
-
-		if poNumber, ok := asset.TypeFields[fmt.Sprintf("po_%d", assetTypeID)].(string); ok {
-			if err := d.Set("po_number", poNumber); err != nil {
-				return diag.FromErr(err)
-			}
-		}
-
-		if owner, ok := asset.TypeFields[fmt.Sprintf("owner_%d", assetTypeID)].(string); ok {
-			if err := d.Set("owner", owner); err != nil {
-				return diag.FromErr(err)
-			}
-		}
-
-		if approver, ok := asset.TypeFields[fmt.Sprintf("approved_by_%d", assetTypeID)].(string); ok {
-			if err := d.Set("approver", approver); err != nil {
-				return diag.FromErr(err)
-			}
-		}
-
-		if environment, ok := asset.TypeFields[fmt.Sprintf("environment_%d", assetTypeID)].(string); ok {
-			if err := d.Set("environment", environment); err != nil {
-				return diag.FromErr(err)
-			}
-		}
-
-		if active, ok := asset.TypeFields[fmt.Sprintf("active_%d", assetTypeID)].(string); ok {
-			if err := d.Set("active", active); err != nil {
-				return diag.FromErr(err)
-			}
-		}
+	if err := setTypeFieldAttributes(d, config, asset, gcpProjectTypeFields); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := setAttributeTypeFieldsAll(d, config, asset, gcpProjectTypeFields); err != nil {
+		return diag.FromErr(err)
 	}
 
 	return nil
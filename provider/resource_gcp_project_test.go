@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestResourceGCPProject_lifecycle(t *testing.T) {
+	h := newTestHarness(t)
+
+	config := map[string]interface{}{
+		"project_name": "Data Platform",
+		"project_id":   "data-platform-prod",
+		"po_number":    "PO-7",
+		"owner":        "gcp.admin@example.com",
+		"environment":  "Production",
+	}
+
+	// Create
+	state := h.apply("freshservice_gcp_project", nil, config)
+	requireAttributes(t, state, map[string]string{
+		"project_name":  "Data Platform",
+		"project_id":    "data-platform-prod",
+		"active":        "Yes",
+		"asset_type_id": "56000979438",
+	})
+
+	displayID, _ := strconv.Atoi(state.ID)
+	if projectID := h.fake.Asset(displayID)["type_fields"].(map[string]interface{})["project_id_56000979438"]; projectID != "data-platform-prod" {
+		t.Errorf("project_id_56000979438 = %#v", projectID)
+	}
+
+	// Read
+	state = h.refresh("freshservice_gcp_project", state)
+	h.requireNoPlan("freshservice_gcp_project", state, config)
+
+	// Update
+	config["po_number"] = "PO-8"
+	state = h.apply("freshservice_gcp_project", state, config)
+	requireAttributes(t, state, map[string]string{"po_number": "PO-8"})
+	h.requireNoPlan("freshservice_gcp_project", h.refresh("freshservice_gcp_project", state), config)
+
+	// Drift
+	h.fake.UpdateAsset(displayID, nil, map[string]interface{}{"environment_56000979438": "Test"})
+	state = h.refresh("freshservice_gcp_project", state)
+	requireAttributes(t, state, map[string]string{"environment": "Test"})
+	state = h.apply("freshservice_gcp_project", state, config)
+	requireAttributes(t, state, map[string]string{"environment": "Production"})
+
+	// Import
+	imported := h.importState("freshservice_gcp_project", state.ID)
+	requireAttributes(t, imported, map[string]string{
+		"project_id": "data-platform-prod",
+		"po_number":  "PO-8",
+	})
+
+	// Delete
+	h.destroy("freshservice_gcp_project", state)
+	if asset := h.fake.Asset(displayID); asset["trashed"] != true {
+		t.Errorf("expected asset %d to be in trash", displayID)
+	}
+}
+
+func TestResourceGCPProject_deletedOutsideTerraform(t *testing.T) {
+	h := newTestHarness(t)
+
+	state := h.apply("freshservice_gcp_project", nil, map[string]interface{}{
+		"project_name": "Sandbox",
+		"project_id":   "sandbox-1234",
+	})
+
+	displayID, _ := strconv.Atoi(state.ID)
+	h.fake.DeleteAsset(displayID)
+
+	if h.refresh("freshservice_gcp_project", state) != nil {
+		t.Fatal("expected the deleted project to be removed from state")
+	}
+}
+
+func TestResourceGCPProject_nonStringTypeFields(t *testing.T) {
+	h := newTestHarness(t)
+
+	config := map[string]interface{}{
+		"project_name": "Billing",
+		"project_id":   "billing-prod",
+		"po_number":    "1042",
+		"active":       "true",
+	}
+	state := h.apply("freshservice_gcp_project", nil, config)
+
+	// Numbers and booleans returned by the API are read like the strings that were sent
+	displayID, _ := strconv.Atoi(state.ID)
+	h.fake.UpdateAsset(displayID, nil, map[string]interface{}{
+		"po_56000979438":     float64(1042),
+		"active_56000979438": true,
+	})
+	state = h.refresh("freshservice_gcp_project", state)
+	requireAttributes(t, state, map[string]string{"po_number": "1042", "active": "true"})
+	h.requireNoPlan("freshservice_gcp_project", state, config)
+
+	// A changed number shows up as drift instead of being dropped
+	h.fake.UpdateAsset(displayID, nil, map[string]interface{}{"po_56000979438": float64(1043)})
+	state = h.refresh("freshservice_gcp_project", state)
+	requireAttributes(t, state, map[string]string{"po_number": "1043"})
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRetry_rateLimited(t *testing.T) {
+	h := newTestHarness(t)
+
+	// Load the asset type fields first, so only the create is throttled
+	h.apply("freshservice_asset", nil, map[string]interface{}{"name": "Hub", "asset_type_id": testHardwareTypeID})
+	before := h.fake.CountRequests("POST /api/v2/assets")
+
+	// Rate limited requests are retried, even when they are not idempotent
+	h.fake.Throttle(2)
+	h.apply("freshservice_asset", nil, map[string]interface{}{"name": "Switch", "asset_type_id": testHardwareTypeID})
+	if n := h.fake.CountRequests("POST /api/v2/assets") - before; n != 3 {
+		t.Errorf("expected 3 create attempts, got %d", n)
+	}
+
+	// Retries stop after max_retries
+	h.config.MaxRetries = 1
+	h.fake.Throttle(5)
+	_, err := h.tryApply("freshservice_asset", nil, map[string]interface{}{"name": "Router", "asset_type_id": testHardwareTypeID})
+	if err == nil || !strings.Contains(err.Error(), "429") {
+		t.Fatalf("expected a rate limit error, got %v", err)
+	}
+}
+
+func TestRetry_serverErrors(t *testing.T) {
+	h := newTestHarness(t)
+
+	state := h.apply("freshservice_asset", nil, map[string]interface{}{"name": "Switch", "asset_type_id": testHardwareTypeID})
+
+	// Reads are retried on server errors
+	h.fake.Fail(2, http.StatusServiceUnavailable)
+	if h.refresh("freshservice_asset", state) == nil {
+		t.Fatal("expected the asset to be read after retrying")
+	}
+
+	// Creates are not, so assets are never created twice
+	before := h.fake.CountRequests("POST /api/v2/assets")
+	h.fake.Fail(1, http.StatusBadGateway)
+	_, err := h.tryApply("freshservice_asset", nil, map[string]interface{}{"name": "Router", "asset_type_id": testHardwareTypeID})
+	if err == nil || !strings.Contains(err.Error(), "502") {
+		t.Fatalf("expected a bad gateway error, got %v", err)
+	}
+	if n := h.fake.CountRequests("POST /api/v2/assets") - before; n != 1 {
+		t.Errorf("expected 1 create attempt, got %d", n)
+	}
+}
+
+func TestRetry_invalidCredentials(t *testing.T) {
+	h := newTestHarness(t)
+	h.config.APIKey = "wrong"
+
+	_, err := h.tryApply("freshservice_asset_type", nil, map[string]interface{}{"name": "Printer"})
+	if err == nil || !strings.Contains(err.Error(), "You have to be logged in") {
+		t.Fatalf("expected an authentication error, got %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lcp-llp/terraform-provider-freshservice/freshservice"
+)
+
+// assetRestoreIfTrashedSchema returns the schema of restore_if_trashed, which restores
+// the asset of a resource from the trash instead of creating a new one
+func assetRestoreIfTrashedSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Description: "Restore the asset from the trash when it was moved there outside of Terraform, instead of creating a new asset (default: false)",
+	}
+}
+
+// assetTrashedSchema returns the schema of trashed, set when the asset of a resource
+// with restore_if_trashed is found in the trash
+func assetTrashedSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Computed:    true,
+		Description: "Whether the asset is in the trash. With restore_if_trashed, the next apply restores it",
+	}
+}
+
+// readTrashedAsset looks up in the trash an asset that was not found, when the resource
+// has restore_if_trashed. It returns nil when the asset is not in the trash, so the
+// resource is removed from state as usual.
+func readTrashedAsset(ctx context.Context, d *schema.ResourceData, config *Config, displayID int) (*freshservice.Asset, error) {
+	if !d.Get("restore_if_trashed").(bool) {
+		return nil, nil
+	}
+
+	asset, err := config.Assets.GetTrashed(ctx, displayID)
+	if errors.Is(err, freshservice.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tflog.Warn(ctx, "Asset is in the trash, it will be restored on the next apply", map[string]interface{}{"display_id": displayID})
+	return asset, nil
+}
+
+// customizeTrashedAsset plans the restore of an asset found in the trash
+func customizeTrashedAsset(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.Get("trashed").(bool) || !d.Get("restore_if_trashed").(bool) {
+		return nil
+	}
+	return d.SetNew("trashed", false)
+}
+
+// restoreTrashedAsset restores the asset from the trash when the plan restores it. It
+// runs before any other change, since assets in the trash can't be updated.
+func restoreTrashedAsset(ctx context.Context, d *schema.ResourceData, config *Config) diag.Diagnostics {
+	if trashed, _ := d.GetChange("trashed"); !trashed.(bool) {
+		return nil
+	}
+
+	displayID, err := resourceIntID(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Info(ctx, "Restoring asset from the trash", map[string]interface{}{"display_id": displayID})
+
+	if err := config.Assets.Restore(ctx, displayID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
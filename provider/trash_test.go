@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestRestoreIfTrashed(t *testing.T) {
+	forEachAssetResource(t, func(t *testing.T, h *testHarness, name string, fixture assetResourceFixture) {
+		config := fixture.config
+		config["restore_if_trashed"] = true
+
+		state := h.apply(name, nil, config)
+		displayID, _ := strconv.Atoi(state.ID)
+		h.fake.TrashAsset(displayID)
+
+		// The asset stays in state, marked as trashed
+		state = h.refresh(name, state)
+		if state == nil {
+			t.Fatal("expected the trashed asset to stay in state")
+		}
+		requireAttributes(t, state, map[string]string{"id": strconv.Itoa(displayID), "trashed": "true"})
+
+		// The next plan restores the asset instead of creating a new one
+		diff, err := h.plan(name, state, config)
+		if err != nil || diff == nil || diff.Destroy || diff.RequiresNew() {
+			t.Fatalf("expected a plan updating the asset, got %v (%v)", diff, err)
+		}
+		if attr, ok := diff.Attributes["trashed"]; !ok || attr.New != "false" {
+			t.Errorf("expected trashed to be planned as false, got %#v", attr)
+		}
+
+		state = h.apply(name, state, config)
+		requireAttributes(t, state, map[string]string{"id": strconv.Itoa(displayID), "trashed": "false"})
+		if asset := h.fake.Asset(displayID); asset["trashed"] == true {
+			t.Errorf("expected asset %d to be restored", displayID)
+		}
+		if n := h.fake.CountRequests("PUT /api/v2/assets/" + state.ID + "/restore"); n != 1 {
+			t.Errorf("expected one restore request, got %d", n)
+		}
+		h.requireNoPlan(name, h.refresh(name, state), config)
+	})
+}
+
+func TestRestoreIfTrashed_deleted(t *testing.T) {
+	h := newTestHarness(t)
+
+	config := map[string]interface{}{
+		"account_name":       "Sandbox",
+		"account_id":         "210987654321",
+		"restore_if_trashed": true,
+	}
+
+	state := h.apply("freshservice_aws_account", nil, config)
+	displayID, _ := strconv.Atoi(state.ID)
+	h.fake.DeleteAsset(displayID)
+
+	// An asset that is not in the trash is removed from state as usual
+	if h.refresh("freshservice_aws_account", state) != nil {
+		t.Fatal("expected the deleted asset to be removed from state")
+	}
+}
+
+func TestRestoreIfTrashed_readOnly(t *testing.T) {
+	h := newTestHarness(t)
+
+	config := map[string]interface{}{
+		"account_name":       "Sandbox",
+		"account_id":         "210987654321",
+		"restore_if_trashed": true,
+	}
+
+	state := h.apply("freshservice_aws_account", nil, config)
+	displayID, _ := strconv.Atoi(state.ID)
+	h.fake.TrashAsset(displayID)
+
+	// Finding the asset in the trash only takes GET requests
+	h.config.ReadOnly = true
+	h.config.Client.ReadOnly = true
+	state = h.refresh("freshservice_aws_account", state)
+	requireAttributes(t, state, map[string]string{"trashed": "true"})
+
+	if _, err := h.tryApply("freshservice_aws_account", state, config); err == nil {
+		t.Error("expected restoring the asset to fail when the provider is read only")
+	}
+}
@@ -0,0 +1,8 @@
+package provider
+
+// providerName is the name of the provider reported in the User-Agent header
+const providerName = "terraform-provider-freshservice"
+
+// Version is the version of the provider, set at build time with
+// -ldflags "-X github.com/lcp-llp/terraform-provider-freshservice/provider.Version=1.2.3"
+var Version = "dev"
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// assetWorkspaceID returns the workspace a new asset is created in: the workspace_id
+// of the resource, or else the default_workspace_id of the provider. It returns nil
+// when neither is set, so Freshservice uses its default workspace.
+func assetWorkspaceID(d *schema.ResourceData, config *Config) *int {
+	if workspaceID, ok := d.GetOk("workspace_id"); ok {
+		id := workspaceID.(int)
+		return &id
+	}
+	if config.DefaultWorkspaceID != 0 {
+		id := config.DefaultWorkspaceID
+		return &id
+	}
+	return nil
+}
+
+// moveAssetWorkspace moves the asset to the configured workspace_id when it changed.
+// Assets can't change workspace through a regular update, so this goes through the
+// move workspace endpoint and keeps the asset instead of recreating it.
+func moveAssetWorkspace(ctx context.Context, d *schema.ResourceData, config *Config) diag.Diagnostics {
+	if !d.HasChange("workspace_id") {
+		return nil
+	}
+	workspaceID, ok := d.GetOk("workspace_id")
+	if !ok {
+		return nil
+	}
+
+	displayID, err := resourceIntID(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Debug(ctx, "Moving asset to another workspace", map[string]interface{}{"display_id": displayID, "workspace_id": workspaceID.(int)})
+
+	if err := config.Assets.MoveWorkspace(ctx, displayID, workspaceID.(int)); err != nil {
+		return apiErrorDiagnostics(err, attributeFieldResolver(map[string]string{"workspace_id": "workspace_id"}))
+	}
+
+	return nil
+}
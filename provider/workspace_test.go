@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestAssetWorkspace(t *testing.T) {
+	forEachAssetResource(t, func(t *testing.T, h *testHarness, name string, fixture assetResourceFixture) {
+		config := fixture.config
+
+		// Without a workspace, the asset lands in the default workspace of the account
+		state := h.apply(name, nil, config)
+		requireAttributes(t, state, map[string]string{"workspace_id": strconv.Itoa(testITWorkspaceID)})
+		h.requireNoPlan(name, h.refresh(name, state), config)
+
+		// The provider default applies to new assets
+		h.config.DefaultWorkspaceID = testCloudOpsWorkspaceID
+		other := h.apply(name, nil, config)
+		requireAttributes(t, other, map[string]string{"workspace_id": strconv.Itoa(testCloudOpsWorkspaceID)})
+
+		// Changing the workspace moves the asset instead of replacing it
+		moved := copyJSON(config)
+		moved["workspace_id"] = testCloudOpsWorkspaceID
+		diff, err := h.plan(name, state, moved)
+		if err != nil || diff == nil || diff.RequiresNew() {
+			t.Fatalf("expected an in-place update, got %v (%v)", diff, err)
+		}
+		newState := h.apply(name, state, moved)
+		requireAttributes(t, newState, map[string]string{
+			"id":           state.ID,
+			"workspace_id": strconv.Itoa(testCloudOpsWorkspaceID),
+		})
+		if n := h.fake.CountRequests("PUT /api/v2/assets/" + state.ID + "/move_workspace"); n != 1 {
+			t.Errorf("expected 1 move request, got %d", n)
+		}
+		h.requireNoPlan(name, h.refresh(name, newState), moved)
+
+		// Unknown workspaces are reported on workspace_id
+		moved["workspace_id"] = 99
+		_, err = h.tryApply(name, newState, moved)
+		if err == nil || !strings.Contains(err.Error(), "workspace_id: ") {
+			t.Fatalf("expected an error on workspace_id, got %v", err)
+		}
+	})
+}